@@ -15,10 +15,20 @@ const (
 	// keyPrefix is the hexadecimal prefix used for addresses and keys.
 	keyPrefix = "0x"
 
+	// ed25519Schema is the authentication key scheme of single ED25519 key accounts.
+	ed25519Schema = 0x00
+	// multiEd25519Schema is the authentication key scheme of K-of-N ED25519 accounts.
+	multiEd25519Schema = 0x01
+	// singleKeySchema is the authentication key scheme of single-key accounts (e.g. secp256k1).
+	singleKeySchema = 0x02
+	// multiKeySchema is the authentication key scheme of K-of-N accounts with keys of any scheme.
+	multiKeySchema = 0x03
+
 	deriveResourceAccountSchema = 0xFF
 )
 
 // Account represents a Cedra blockchain account with its cryptographic keys and address.
+// It is the default in-memory ED25519 implementation of Signer.
 type Account struct {
 	// AccountAddress is the 32-byte account address derived from the public key.
 	AccountAddress [32]byte
@@ -46,24 +56,68 @@ func NewAccount(hexKey string) (Account, error) {
 		return Account{}, errors.New("can't extract account public key from account private key")
 	}
 
-	hasher := sha3.New256()
-	for _, b := range [][]byte{publicKey, {0}} {
-		hasher.Write(b)
-	}
-	accountAddress := hasher.Sum([]byte{})
-
 	return Account{
 		PrivateKey:     privateKey,
 		PublicKey:      publicKey,
-		AccountAddress: [32]byte(accountAddress),
+		AccountAddress: deriveAccountAddress(publicKey, ed25519Schema),
 	}, nil
 }
 
+// deriveAccountAddress derives the address of an account from its encoded public key and scheme byte.
+func deriveAccountAddress(encodedPublicKey []byte, schema byte) [32]byte {
+	hasher := sha3.New256()
+	for _, b := range [][]byte{encodedPublicKey, {schema}} {
+		hasher.Write(b)
+	}
+
+	return [32]byte(hasher.Sum([]byte{}))
+}
+
 // GetAccountAddressString returns the hexadecimal string representation of the account address.
 func (a Account) GetAccountAddressString() string {
 	return hex.EncodeToString(a.AccountAddress[:])
 }
 
+// GetAccountAddress returns the 32-byte account address.
+func (a Account) GetAccountAddress() [32]byte {
+	return a.AccountAddress
+}
+
+// GetPublicKey returns the ED25519 public key of the account.
+func (a Account) GetPublicKey() []byte {
+	return a.PublicKey
+}
+
+// KeyScheme returns KeySchemeEd25519.
+func (a Account) KeyScheme() KeyScheme {
+	return KeySchemeEd25519
+}
+
+// Sign signs the message with the account's ED25519 private key.
+// Returns an error if the account has no valid private key, e.g. when it was decoded from a transaction.
+func (a Account) Sign(message []byte) ([]byte, error) {
+	if len(a.PrivateKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("can't sign message: account has no valid private key")
+	}
+
+	return ed25519.Sign(a.PrivateKey, message), nil
+}
+
+// Verify reports whether the signature is a valid ED25519 signature of the message by the account.
+func (a Account) Verify(message []byte, signature []byte) bool {
+	return len(a.PublicKey) == ed25519.PublicKeySize && ed25519.Verify(a.PublicKey, message, signature)
+}
+
+// NewAuthenticator creates an ED25519 transaction authenticator for a signature produced by Sign.
+func (a Account) NewAuthenticator(signature []byte) CedraAuthenticator {
+	return NewCedraAuthenticator(a.PublicKey, signature)
+}
+
+// SimulationSignature returns a zeroed ED25519 signature.
+func (a Account) SimulationSignature() []byte {
+	return make([]byte, ed25519.SignatureSize)
+}
+
 // NewAccountAddress parses a hexadecimal address string and returns a 32-byte address.
 // The address can optionally include the "0x" prefix.
 // Returns an error if the address format is invalid or exceeds 32 bytes.
@@ -0,0 +1,192 @@
+package cedra
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Error codes returned by the Cedra node API in APIError.ErrorCode.
+const (
+	// APIErrorCodeAccountNotFound means that the requested account doesn't exist.
+	APIErrorCodeAccountNotFound = "account_not_found"
+	// APIErrorCodeResourceNotFound means that the requested resource doesn't exist.
+	APIErrorCodeResourceNotFound = "resource_not_found"
+	// APIErrorCodeModuleNotFound means that the requested module doesn't exist.
+	APIErrorCodeModuleNotFound = "module_not_found"
+	// APIErrorCodeTransactionNotFound means that the requested transaction is unknown to the node.
+	APIErrorCodeTransactionNotFound = "transaction_not_found"
+	// APIErrorCodeTableItemNotFound means that the requested table item doesn't exist.
+	APIErrorCodeTableItemNotFound = "table_item_not_found"
+	// APIErrorCodeBlockNotFound means that the requested block doesn't exist.
+	APIErrorCodeBlockNotFound = "block_not_found"
+	// APIErrorCodeVersionNotFound means that the requested ledger version doesn't exist yet.
+	APIErrorCodeVersionNotFound = "version_not_found"
+	// APIErrorCodeVersionPruned means that the requested ledger version was pruned by the node.
+	APIErrorCodeVersionPruned = "version_pruned"
+	// APIErrorCodeInvalidInput means that the request is malformed.
+	APIErrorCodeInvalidInput = "invalid_input"
+	// APIErrorCodeInvalidTransactionUpdate means that a transaction with the same sequence number is already pending.
+	APIErrorCodeInvalidTransactionUpdate = "invalid_transaction_update"
+	// APIErrorCodeSequenceNumberTooOld means that the transaction sequence number was already used.
+	APIErrorCodeSequenceNumberTooOld = "sequence_number_too_old"
+	// APIErrorCodeVMError means that the transaction was rejected by the VM; see APIError.VMErrorCode.
+	APIErrorCodeVMError = "vm_error"
+	// APIErrorCodeRejectedByFilter means that the transaction was rejected by the node transaction filter.
+	APIErrorCodeRejectedByFilter = "rejected_by_filter"
+	// APIErrorCodeMempoolIsFull means that the node mempool can't accept more transactions.
+	APIErrorCodeMempoolIsFull = "mempool_is_full"
+	// APIErrorCodeInternalError means that the node failed to process the request.
+	APIErrorCodeInternalError = "internal_error"
+	// APIErrorCodeAPIDisabled means that the requested endpoint is disabled on the node.
+	APIErrorCodeAPIDisabled = "api_disabled"
+)
+
+// vmErrorCodes maps the VM status codes of transactions rejected by the node to the errors they match.
+var vmErrorCodes = map[uint64]error{
+	3:    ErrSequenceNumberTooOld,
+	4:    ErrSequenceNumberTooNew,
+	5:    ErrInsufficientBalance,
+	6:    ErrTransactionExpired,
+	4002: ErrOutOfGas,
+}
+
+// Headers of the Cedra node API responses describing the state of the ledger.
+const (
+	// headerChainID is the chain identifier of the node.
+	headerChainID = "X-Cedra-Chain-Id"
+	// headerLedgerVersion is the latest ledger version of the node.
+	headerLedgerVersion = "X-Cedra-Ledger-Version"
+	// headerLedgerOldestVersion is the oldest ledger version stored by the node.
+	headerLedgerOldestVersion = "X-Cedra-Ledger-Oldest-Version"
+	// headerLedgerTimestamp is the timestamp of the latest ledger version in microseconds.
+	headerLedgerTimestamp = "X-Cedra-Ledger-TimestampUsec"
+	// headerEpoch is the current epoch.
+	headerEpoch = "X-Cedra-Epoch"
+	// headerBlockHeight is the latest block height of the node.
+	headerBlockHeight = "X-Cedra-Block-Height"
+	// headerOldestBlockHeight is the oldest block height stored by the node.
+	headerOldestBlockHeight = "X-Cedra-Oldest-Block-Height"
+)
+
+// LedgerHeaders describes the state of the ledger of the node that answered a request.
+// Fields are zero when the node didn't send the corresponding header.
+type LedgerHeaders struct {
+	// ChainID identifies the blockchain network of the node.
+	ChainID uint8
+	// LedgerVersion is the latest ledger version of the node.
+	LedgerVersion uint64
+	// LedgerOldestVersion is the oldest ledger version the node still stores.
+	LedgerOldestVersion uint64
+	// LedgerTimestampUsec is the timestamp of the latest ledger version in microseconds.
+	LedgerTimestampUsec uint64
+	// Epoch is the current epoch.
+	Epoch uint64
+	// BlockHeight is the latest block height of the node.
+	BlockHeight uint64
+	// OldestBlockHeight is the oldest block height the node still stores.
+	OldestBlockHeight uint64
+}
+
+// newLedgerHeaders reads the ledger headers of a node response.
+func newLedgerHeaders(header http.Header) LedgerHeaders {
+	parse := func(name string) uint64 {
+		value, _ := strconv.ParseUint(header.Get(name), 10, 64)
+
+		return value
+	}
+
+	return LedgerHeaders{
+		ChainID:             uint8(parse(headerChainID)),
+		LedgerVersion:       parse(headerLedgerVersion),
+		LedgerOldestVersion: parse(headerLedgerOldestVersion),
+		LedgerTimestampUsec: parse(headerLedgerTimestamp),
+		Epoch:               parse(headerEpoch),
+		BlockHeight:         parse(headerBlockHeight),
+		OldestBlockHeight:   parse(headerOldestBlockHeight),
+	}
+}
+
+// APIError is the error returned for non-successful responses of the Cedra node API.
+// Use errors.As to inspect it; it also matches ErrSequenceNumberTooOld, ErrSequenceNumberTooNew,
+// ErrInsufficientBalance, ErrTransactionExpired and ErrOutOfGas with errors.Is for rejected transactions.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+	// Message is the error message, or the raw response body if it isn't a node API error.
+	Message string `json:"message"`
+	// ErrorCode is the Cedra error code (e.g. "account_not_found"), if any.
+	ErrorCode string `json:"error_code"`
+	// VMErrorCode is the VM status code of transactions rejected by the VM, if any.
+	VMErrorCode *uint64 `json:"vm_error_code"`
+	// Ledger describes the state of the ledger of the node.
+	Ledger LedgerHeaders `json:"-"`
+	// RetryAfter is the delay requested by the node in the Retry-After header before retrying, if any.
+	RetryAfter time.Duration `json:"-"`
+}
+
+// newAPIError creates an APIError from a non-successful node response and its body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		apiErr = &APIError{Message: string(body)}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	apiErr.Ledger = newLedgerHeaders(resp.Header)
+	apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	return apiErr
+}
+
+// parseRetryAfter parses a Retry-After header value, either a number of seconds or an HTTP date.
+// Returns 0 if the value is missing or invalid.
+func parseRetryAfter(value string) time.Duration {
+	if seconds, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0)
+	}
+
+	return 0
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+	if e.ErrorCode != "" {
+		message += " (error_code: " + e.ErrorCode
+		if e.VMErrorCode != nil {
+			message += ", vm_error_code: " + strconv.FormatUint(*e.VMErrorCode, 10)
+		}
+		message += ")"
+	}
+
+	return message
+}
+
+// Is reports whether the error matches the target error, based on its VM error code or error code.
+func (e *APIError) Is(target error) bool {
+	if e.VMErrorCode != nil && vmErrorCodes[*e.VMErrorCode] == target {
+		return true
+	}
+
+	return e.ErrorCode == APIErrorCodeSequenceNumberTooOld && target == ErrSequenceNumberTooOld
+}
+
+// IsNotFound reports whether the requested account, resource, module, transaction or other item doesn't exist.
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether the request was rejected because of rate limiting.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsMempoolFull reports whether the transaction was rejected because the node mempool is full.
+func (e *APIError) IsMempoolFull() bool {
+	return e.ErrorCode == APIErrorCodeMempoolIsFull
+}
@@ -1,18 +1,60 @@
 package cedra
 
-import "github.com/spf13/cast"
+import (
+	"bytes"
+	"crypto/ed25519"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
 
 const (
-	// txVariant is the transaction variant identifier for Cedra transactions.
+	// txVariant is the transaction variant identifier for Cedra transactions signed with a single ED25519 key.
 	txVariant uint64 = 0
+	// multiEd25519TxVariant is the transaction variant identifier for transactions signed by a K-of-N ED25519 account.
+	multiEd25519TxVariant uint64 = 1
+	// multiAgentTxVariant is the transaction variant identifier for transactions with secondary signers.
+	multiAgentTxVariant uint64 = 2
+	// feePayerTxVariant is the transaction variant identifier for transactions whose gas fees are paid by a fee payer.
+	feePayerTxVariant uint64 = 3
+	// singleSenderTxVariant is the transaction variant identifier for transactions whose sender
+	// is authenticated by an account authenticator (e.g. a single-key secp256k1 account).
+	singleSenderTxVariant uint64 = 4
+)
+
+const (
+	// accountAuthEd25519Variant is the account authenticator variant for ED25519 keys.
+	accountAuthEd25519Variant uint64 = 0
+	// accountAuthMultiEd25519Variant is the account authenticator variant for K-of-N ED25519 keys.
+	accountAuthMultiEd25519Variant uint64 = 1
+	// accountAuthSingleKeyVariant is the account authenticator variant for single keys of any scheme.
+	accountAuthSingleKeyVariant uint64 = 2
+	// accountAuthMultiKeyVariant is the account authenticator variant for K-of-N keys of any scheme.
+	accountAuthMultiKeyVariant uint64 = 3
+)
+
+const (
+	// anyKeyEd25519Variant is the AnyPublicKey/AnySignature variant for ED25519 keys.
+	anyKeyEd25519Variant uint64 = 0
+	// anyKeySecp256k1Variant is the AnyPublicKey/AnySignature variant for secp256k1 ECDSA keys.
+	anyKeySecp256k1Variant uint64 = 1
 )
 
+// AuthData is implemented by the authentication data carried by authenticators:
+// SenderAuth, SingleKeyAuth, MultiKeyAuth, MultiAgentAuth, FeePayerAuth and AccountAuthenticator.
+type AuthData interface {
+	// EncodeBSC encodes the authentication data into BCS format.
+	EncodeBSC() []byte
+}
+
 // CedraAuthenticator represents the authentication information for a Cedra transaction.
 type CedraAuthenticator struct {
 	// Variant specifies the transaction variant type.
 	Variant uint64
-	// Auth contains the sender authentication data (public key and signature).
-	Auth SenderAuth
+	// Auth contains the sender authentication data: a SenderAuth (public key and signature) for ED25519
+	// and MultiEd25519 transactions, an AccountAuthenticator for single sender transactions,
+	// a MultiAgentAuth for multi-agent transactions or a FeePayerAuth for fee payer transactions.
+	Auth AuthData
 }
 
 // EncodeBSC encodes the authenticator into Binary Canonical Serialization (BCS) format.
@@ -26,6 +68,43 @@ func (a CedraAuthenticator) EncodeBSC() []byte {
 	return bcs.GetBytes()
 }
 
+// ToAccountAuthenticator returns the account authenticator of a transaction authenticator built by a Signer,
+// as used for each signer of fee payer and multi-agent transactions.
+// Returns an error if the authenticator already combines several accounts.
+func (a CedraAuthenticator) ToAccountAuthenticator() (AccountAuthenticator, error) {
+	switch a.Variant {
+	case txVariant:
+		return AccountAuthenticator{Variant: accountAuthEd25519Variant, Auth: a.Auth}, nil
+	case multiEd25519TxVariant:
+		return AccountAuthenticator{Variant: accountAuthMultiEd25519Variant, Auth: a.Auth}, nil
+	case singleSenderTxVariant:
+		if accountAuth, ok := a.Auth.(AccountAuthenticator); ok {
+			return accountAuth, nil
+		}
+	}
+
+	return AccountAuthenticator{}, errors.Errorf("can't convert authenticator variant %d to an account authenticator", a.Variant)
+}
+
+// AccountAuthenticator represents the authentication information of a single account.
+type AccountAuthenticator struct {
+	// Variant specifies the account authenticator type.
+	Variant uint64
+	// Auth contains the account authentication data.
+	Auth AuthData
+}
+
+// EncodeBSC encodes the account authenticator into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the authenticator.
+func (a AccountAuthenticator) EncodeBSC() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(a.Variant)
+	bcs.WriteRawBytes(a.Auth.EncodeBSC())
+
+	return bcs.GetBytes()
+}
+
 // SenderAuth contains the authentication data for a transaction sender.
 type SenderAuth struct {
 	// PKey is the public key of the transaction sender.
@@ -47,6 +126,112 @@ func (a SenderAuth) EncodeBSC() []byte {
 	return bcs.GetBytes()
 }
 
+// SingleKeyAuth contains the authentication data of a single-key account, whose key can use any scheme.
+type SingleKeyAuth struct {
+	// Variant specifies the key scheme of both the public key and the signature.
+	Variant uint64
+	// PKey is the public key of the account.
+	PKey []byte
+	// Signature is the signature of the transaction.
+	Signature []byte
+}
+
+// EncodeBSC encodes the single-key authentication into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the authentication data.
+func (a SingleKeyAuth) EncodeBSC() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.WriteRawBytes(encodeAnyPublicKey(a.Variant, a.PKey))
+	bcs.EncodeEnum(a.Variant)
+	bcs.EncodeBytes(a.Signature)
+
+	return bcs.GetBytes()
+}
+
+// MultiKeyAuth contains the authentication data of a K-of-N multi-key account.
+type MultiKeyAuth struct {
+	// PKey is the encoded multi-key public key: the public keys of the account and the number of required signatures.
+	PKey []byte
+	// Signature is the encoded multi-key signature: the partial signatures and the bitmap of the signing keys.
+	Signature []byte
+}
+
+// EncodeBSC encodes the multi-key authentication into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the authentication data.
+func (a MultiKeyAuth) EncodeBSC() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.WriteRawBytes(a.PKey)
+	bcs.WriteRawBytes(a.Signature)
+
+	return bcs.GetBytes()
+}
+
+// AnyPublicKey is a public key tagged with its key scheme, as used by single-key and multi-key accounts.
+type AnyPublicKey struct {
+	// Variant specifies the key scheme.
+	Variant uint64
+	// PKey is the raw public key.
+	PKey []byte
+}
+
+// NewAnyPublicKey returns the public key of the signer tagged with its key scheme.
+// Signers with the KeySchemeEd25519 or KeySchemeSecp256k1 scheme are supported. Returns an error for other signers.
+func NewAnyPublicKey(signer Signer) (AnyPublicKey, error) {
+	switch signer.KeyScheme() {
+	case KeySchemeEd25519:
+		return AnyPublicKey{Variant: anyKeyEd25519Variant, PKey: signer.GetPublicKey()}, nil
+	case KeySchemeSecp256k1:
+		return AnyPublicKey{Variant: anyKeySecp256k1Variant, PKey: signer.GetPublicKey()}, nil
+	default:
+		return AnyPublicKey{}, errors.Errorf("signer uses an unsupported key scheme %q", signer.KeyScheme())
+	}
+}
+
+// ToBCSBytes encodes the public key into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the public key.
+func (k AnyPublicKey) ToBCSBytes() []byte {
+	return encodeAnyPublicKey(k.Variant, k.PKey)
+}
+
+// UnmarshalBCS decodes a public key encoded with ToBCSBytes.
+func (k *AnyPublicKey) UnmarshalBCS(bcs *BCSDecoder) error {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return errors.Wrap(err, "can't decode public key variant")
+	}
+	pKey, err := bcs.DecodeBytes()
+	if err != nil {
+		return errors.Wrap(err, "can't decode public key")
+	}
+	*k = AnyPublicKey{Variant: variant, PKey: pKey}
+
+	return nil
+}
+
+// Verify reports whether the signature is a valid signature of the message by the public key.
+func (k AnyPublicKey) Verify(message []byte, signature []byte) bool {
+	switch k.Variant {
+	case anyKeyEd25519Variant:
+		return len(k.PKey) == ed25519.PublicKeySize && ed25519.Verify(k.PKey, message, signature)
+	case anyKeySecp256k1Variant:
+		return VerifySecp256k1Signature(k.PKey, message, signature)
+	default:
+		return false
+	}
+}
+
+// encodeAnyPublicKey encodes a public key together with its scheme variant, as used by single-key
+// authenticators and single-key address derivation.
+func encodeAnyPublicKey(variant uint64, pKey []byte) []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(variant)
+	bcs.EncodeBytes(pKey)
+
+	return bcs.GetBytes()
+}
+
 // NewSenderAuth creates a new SenderAuth instance with the provided public key and signature.
 func NewSenderAuth(pKey []byte, signature []byte) SenderAuth {
 	return SenderAuth{
@@ -62,3 +247,176 @@ func NewCedraAuthenticator(pKey []byte, signature []byte) CedraAuthenticator {
 		Auth:    NewSenderAuth(pKey, signature),
 	}
 }
+
+// NewSingleKeyAuthenticator creates a single sender CedraAuthenticator for a single-key account
+// with the provided key scheme variant, public key and signature.
+func NewSingleKeyAuthenticator(variant uint64, pKey []byte, signature []byte) CedraAuthenticator {
+	return CedraAuthenticator{
+		Variant: singleSenderTxVariant,
+		Auth: AccountAuthenticator{
+			Variant: accountAuthSingleKeyVariant,
+			Auth: SingleKeyAuth{
+				Variant:   variant,
+				PKey:      pKey,
+				Signature: signature,
+			},
+		},
+	}
+}
+
+// DecodeCedraAuthenticator decodes an authenticator previously encoded with CedraAuthenticator.EncodeBSC.
+// Returns an error if the input is malformed, uses an unsupported variant or contains trailing bytes.
+func DecodeCedraAuthenticator(data []byte) (CedraAuthenticator, error) {
+	bcs := NewBCSDecoder(data)
+	authenticator, err := decodeCedraAuthenticator(bcs)
+	if err != nil {
+		return CedraAuthenticator{}, err
+	}
+
+	return authenticator, bcs.Finish()
+}
+
+// decodeCedraAuthenticator reads a single authenticator from the decoder.
+func decodeCedraAuthenticator(bcs *BCSDecoder) (CedraAuthenticator, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return CedraAuthenticator{}, errors.Wrap(err, "can't decode authenticator variant")
+	}
+
+	var auth AuthData
+	switch variant {
+	case txVariant, multiEd25519TxVariant:
+		auth, err = decodeSenderAuth(bcs)
+	case singleSenderTxVariant:
+		auth, err = decodeAccountAuthenticator(bcs)
+	case multiAgentTxVariant:
+		auth, err = decodeMultiAgentAuth(bcs)
+	case feePayerTxVariant:
+		auth, err = decodeFeePayerAuth(bcs)
+	default:
+		return CedraAuthenticator{}, errors.Errorf("can't decode authenticator: unsupported variant %d", variant)
+	}
+	if err != nil {
+		return CedraAuthenticator{}, errors.Wrap(err, "can't decode authenticator")
+	}
+
+	return CedraAuthenticator{
+		Variant: variant,
+		Auth:    auth,
+	}, nil
+}
+
+// decodeAccountAuthenticator reads a single account authenticator from the decoder.
+func decodeAccountAuthenticator(bcs *BCSDecoder) (AccountAuthenticator, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't decode account authenticator variant")
+	}
+
+	var auth AuthData
+	switch variant {
+	case accountAuthEd25519Variant, accountAuthMultiEd25519Variant:
+		auth, err = decodeSenderAuth(bcs)
+	case accountAuthSingleKeyVariant:
+		auth, err = decodeSingleKeyAuth(bcs)
+	case accountAuthMultiKeyVariant:
+		auth, err = decodeMultiKeyAuth(bcs)
+	default:
+		return AccountAuthenticator{}, errors.Errorf("can't decode account authenticator: unsupported variant %d", variant)
+	}
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't decode account authenticator")
+	}
+
+	return AccountAuthenticator{
+		Variant: variant,
+		Auth:    auth,
+	}, nil
+}
+
+// decodeSenderAuth reads a public key and signature pair from the decoder.
+func decodeSenderAuth(bcs *BCSDecoder) (SenderAuth, error) {
+	pKey, err := bcs.DecodeBytes()
+	if err != nil {
+		return SenderAuth{}, errors.Wrap(err, "can't decode public key")
+	}
+	signature, err := bcs.DecodeBytes()
+	if err != nil {
+		return SenderAuth{}, errors.Wrap(err, "can't decode signature")
+	}
+
+	return NewSenderAuth(pKey, signature), nil
+}
+
+// decodeSingleKeyAuth reads a single-key public key and signature from the decoder.
+func decodeSingleKeyAuth(bcs *BCSDecoder) (SingleKeyAuth, error) {
+	keyVariant, err := bcs.DecodeEnum()
+	if err != nil {
+		return SingleKeyAuth{}, errors.Wrap(err, "can't decode public key variant")
+	}
+	pKey, err := bcs.DecodeBytes()
+	if err != nil {
+		return SingleKeyAuth{}, errors.Wrap(err, "can't decode public key")
+	}
+	signatureVariant, err := bcs.DecodeEnum()
+	if err != nil {
+		return SingleKeyAuth{}, errors.Wrap(err, "can't decode signature variant")
+	}
+	if keyVariant != signatureVariant {
+		return SingleKeyAuth{}, errors.Errorf("public key variant %d doesn't match signature variant %d", keyVariant, signatureVariant)
+	}
+	signature, err := bcs.DecodeBytes()
+	if err != nil {
+		return SingleKeyAuth{}, errors.Wrap(err, "can't decode signature")
+	}
+
+	return SingleKeyAuth{
+		Variant:   keyVariant,
+		PKey:      pKey,
+		Signature: signature,
+	}, nil
+}
+
+// decodeMultiKeyAuth reads a multi-key public key and signature from the decoder.
+func decodeMultiKeyAuth(bcs *BCSDecoder) (MultiKeyAuth, error) {
+	start := bcs.pos
+	if err := skipAnyKeys(bcs); err != nil {
+		return MultiKeyAuth{}, errors.Wrap(err, "can't decode public keys")
+	}
+	if _, err := bcs.DecodeUint8(); err != nil {
+		return MultiKeyAuth{}, errors.Wrap(err, "can't decode signatures required")
+	}
+	pKey := bytes.Clone(bcs.data[start:bcs.pos])
+
+	start = bcs.pos
+	if err := skipAnyKeys(bcs); err != nil {
+		return MultiKeyAuth{}, errors.Wrap(err, "can't decode signatures")
+	}
+	if _, err := bcs.DecodeBytes(); err != nil {
+		return MultiKeyAuth{}, errors.Wrap(err, "can't decode signatures bitmap")
+	}
+	signature := bytes.Clone(bcs.data[start:bcs.pos])
+
+	return MultiKeyAuth{
+		PKey:      pKey,
+		Signature: signature,
+	}, nil
+}
+
+// skipAnyKeys reads a vector of scheme-tagged public keys or signatures from the decoder.
+func skipAnyKeys(bcs *BCSDecoder) error {
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return err
+	}
+	for range length {
+		if _, err := bcs.DecodeEnum(); err != nil {
+			return err
+		}
+		if _, err := bcs.DecodeBytes(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
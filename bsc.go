@@ -98,9 +98,9 @@ func EncodeToBCSString(value string) []byte {
 }
 
 // EncodeUintToBCS encodes an unsigned integer to BCS format using little-endian byte order.
-// Supports uint8, uint16, uint32, and uint64 types.
+// Supports uint8, uint16, uint32, uint64, Uint128 and Uint256 types.
 // Panics if an unsupported type is provided.
-func EncodeUintToBCS[Type uint8 | uint16 | uint32 | uint64](value Type) []byte {
+func EncodeUintToBCS[Type uint8 | uint16 | uint32 | uint64 | Uint128 | Uint256](value Type) []byte {
 	switch v := any(value).(type) {
 	case uint8:
 		return []byte{cast.ToUint8(v)}
@@ -119,33 +119,33 @@ func EncodeUintToBCS[Type uint8 | uint16 | uint32 | uint64](value Type) []byte {
 		binary.LittleEndian.PutUint64(buf, v)
 
 		return buf
+	case Uint128:
+		return v.ToBCSBytes()
+	case Uint256:
+		return v.ToBCSBytes()
 	}
 
 	panic(errors.New("EncodeUintToBCS: invalid received type"))
 }
 
-// EncodeIntToBCS encodes a signed integer to BCS format using big-endian byte order.
-// Supports int8, int16, int32, and int64 types.
+// EncodeIntToBCS encodes a signed integer to BCS format as two's complement using little-endian byte order,
+// matching the on-chain encoding of the Move i8, i16, i32, i64, i128 and i256 types.
+// Supports int8, int16, int32, int64, Int128 and Int256 types.
 // Panics if an unsupported type is provided.
-func EncodeIntToBCS[Type int8 | int16 | int32 | int64](value Type) []byte {
+func EncodeIntToBCS[Type int8 | int16 | int32 | int64 | Int128 | Int256](value Type) []byte {
 	switch v := any(value).(type) {
 	case int8:
-		return []byte{cast.ToUint8(v)}
+		return EncodeUintToBCS(uint8(v))
 	case int16:
-		buf := make([]byte, 2)
-		binary.BigEndian.PutUint16(buf, cast.ToUint16(v))
-
-		return buf
+		return EncodeUintToBCS(uint16(v))
 	case int32:
-		buf := make([]byte, 4)
-		binary.BigEndian.PutUint32(buf, cast.ToUint32(v))
-
-		return buf
+		return EncodeUintToBCS(uint32(v))
 	case int64:
-		buf := make([]byte, 8)
-		binary.BigEndian.PutUint64(buf, cast.ToUint64(v))
-
-		return buf
+		return EncodeUintToBCS(uint64(v))
+	case Int128:
+		return v.ToBCSBytes()
+	case Int256:
+		return v.ToBCSBytes()
 	}
 
 	panic(errors.New("EncodeIntToBCS: invalid received type"))
@@ -0,0 +1,249 @@
+package cedra
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+const (
+	// maxULEB128Bytes is the maximum number of bytes a ULEB128-encoded uint64 can occupy.
+	maxULEB128Bytes = 10
+)
+
+// BCSDecoder provides Binary Canonical Serialization (BCS) decoding functionality.
+// It reads values in the same order and format as they were written by BCSEncoder.
+type BCSDecoder struct {
+	data []byte
+	pos  int
+}
+
+// NewBCSDecoder creates a new BCS decoder instance over the provided bytes.
+func NewBCSDecoder(data []byte) *BCSDecoder {
+	return &BCSDecoder{data: data}
+}
+
+// Remaining returns the number of bytes that have not been read yet.
+func (bcs *BCSDecoder) Remaining() int {
+	return len(bcs.data) - bcs.pos
+}
+
+// Finish returns an error if the decoder has unread bytes left.
+// It should be called after decoding a complete value to reject trailing data.
+func (bcs *BCSDecoder) Finish() error {
+	if remaining := bcs.Remaining(); remaining != 0 {
+		return errors.Errorf("bcs decoder: %d unexpected trailing bytes", remaining)
+	}
+
+	return nil
+}
+
+// ReadRawBytes reads exactly n bytes from the decoder without any length prefix.
+// The returned slice is a copy and can be safely retained by the caller.
+func (bcs *BCSDecoder) ReadRawBytes(n int) ([]byte, error) {
+	if n < 0 || n > bcs.Remaining() {
+		return nil, errors.Errorf("bcs decoder: can't read %d bytes, %d remaining", n, bcs.Remaining())
+	}
+	value := make([]byte, n)
+	copy(value, bcs.data[bcs.pos:bcs.pos+n])
+	bcs.pos += n
+
+	return value, nil
+}
+
+// DecodeEnum decodes a uint64 value encoded using variable-length encoding (ULEB128).
+// This is used for decoding enum variants and length values.
+// Non-canonical encodings (e.g. with redundant trailing zero groups) are rejected.
+func (bcs *BCSDecoder) DecodeEnum() (uint64, error) {
+	var value uint64
+	for i := 0; i < maxULEB128Bytes; i++ {
+		if bcs.Remaining() == 0 {
+			return 0, errors.New("bcs decoder: unexpected end of input while reading uleb128")
+		}
+		b := bcs.data[bcs.pos]
+		bcs.pos++
+
+		if i == maxULEB128Bytes-1 && b > 1 {
+			return 0, errors.New("bcs decoder: uleb128 value overflows uint64")
+		}
+		value |= uint64(b&0x7F) << (7 * i)
+		if b&0x80 == 0 {
+			if i > 0 && b == 0 {
+				return 0, errors.New("bcs decoder: non-canonical uleb128 encoding")
+			}
+
+			return value, nil
+		}
+	}
+
+	return 0, errors.New("bcs decoder: uleb128 value overflows uint64")
+}
+
+// decodeVariant decodes an enum variant and returns an error if it isn't the expected one.
+func (bcs *BCSDecoder) decodeVariant(expected uint64, name string) error {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return errors.Wrapf(err, "can't decode %s variant", name)
+	}
+	if variant != expected {
+		return errors.Errorf("can't decode %s: unexpected variant %d", name, variant)
+	}
+
+	return nil
+}
+
+// DecodeLength decodes a ULEB128 length prefix and checks that it fits into the remaining input.
+func (bcs *BCSDecoder) DecodeLength() (int, error) {
+	length, err := bcs.DecodeEnum()
+	if err != nil {
+		return 0, err
+	}
+	if length > math.MaxUint32 || length > cast.ToUint64(bcs.Remaining()) {
+		return 0, errors.Errorf("bcs decoder: length %d exceeds remaining input", length)
+	}
+
+	return int(length), nil
+}
+
+// DecodeBytes decodes a byte slice with its ULEB128 length prefix.
+func (bcs *BCSDecoder) DecodeBytes() ([]byte, error) {
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return nil, err
+	}
+
+	return bcs.ReadRawBytes(length)
+}
+
+// DecodeString decodes a string value with its ULEB128 length prefix.
+func (bcs *BCSDecoder) DecodeString() (string, error) {
+	value, err := bcs.DecodeBytes()
+	if err != nil {
+		return "", err
+	}
+
+	return string(value), nil
+}
+
+// DecodeBool decodes a boolean value encoded as a single 0 or 1 byte.
+func (bcs *BCSDecoder) DecodeBool() (bool, error) {
+	value, err := bcs.DecodeUint8()
+	if err != nil {
+		return false, err
+	}
+	switch value {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+
+	return false, errors.Errorf("bcs decoder: invalid bool value %d", value)
+}
+
+// DecodeUint8 decodes a single byte unsigned integer.
+func (bcs *BCSDecoder) DecodeUint8() (uint8, error) {
+	buf, err := bcs.ReadRawBytes(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return buf[0], nil
+}
+
+// DecodeUint16 decodes a little-endian 16-bit unsigned integer.
+func (bcs *BCSDecoder) DecodeUint16() (uint16, error) {
+	buf, err := bcs.ReadRawBytes(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint16(buf), nil
+}
+
+// DecodeUint32 decodes a little-endian 32-bit unsigned integer.
+func (bcs *BCSDecoder) DecodeUint32() (uint32, error) {
+	buf, err := bcs.ReadRawBytes(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint32(buf), nil
+}
+
+// DecodeUint64 decodes a little-endian 64-bit unsigned integer.
+func (bcs *BCSDecoder) DecodeUint64() (uint64, error) {
+	buf, err := bcs.ReadRawBytes(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.LittleEndian.Uint64(buf), nil
+}
+
+// DecodeAddress decodes a fixed 32-byte account address.
+func (bcs *BCSDecoder) DecodeAddress() ([32]byte, error) {
+	buf, err := bcs.ReadRawBytes(32)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return [32]byte(buf), nil
+}
+
+// DecodeFromBCSBytes decodes a length-prefixed byte slice produced by EncodeToBCSBytes.
+// Returns an error if the input is malformed or contains trailing bytes.
+func DecodeFromBCSBytes(data []byte) ([]byte, error) {
+	bcs := NewBCSDecoder(data)
+	value, err := bcs.DecodeBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return value, bcs.Finish()
+}
+
+// DecodeFromBCSString decodes a length-prefixed string produced by EncodeToBCSString.
+// Returns an error if the input is malformed or contains trailing bytes.
+func DecodeFromBCSString(data []byte) (string, error) {
+	bcs := NewBCSDecoder(data)
+	value, err := bcs.DecodeString()
+	if err != nil {
+		return "", err
+	}
+
+	return value, bcs.Finish()
+}
+
+// DecodeUintFromBCS decodes a little-endian unsigned integer produced by EncodeUintToBCS.
+// Returns an error if the input length doesn't match the size of the requested type.
+func DecodeUintFromBCS[Type uint8 | uint16 | uint32 | uint64 | Uint128 | Uint256](data []byte) (Type, error) {
+	var (
+		value   Type
+		decoded any
+		err     error
+	)
+	bcs := NewBCSDecoder(data)
+
+	switch any(value).(type) {
+	case uint8:
+		decoded, err = bcs.DecodeUint8()
+	case uint16:
+		decoded, err = bcs.DecodeUint16()
+	case uint32:
+		decoded, err = bcs.DecodeUint32()
+	case uint64:
+		decoded, err = bcs.DecodeUint64()
+	case Uint128:
+		decoded, err = bcs.DecodeUint128()
+	case Uint256:
+		decoded, err = bcs.DecodeUint256()
+	}
+	if err != nil {
+		return value, err
+	}
+
+	return decoded.(Type), bcs.Finish()
+}
@@ -0,0 +1,232 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+// testPrivateKey is the private key of the account signing the test transactions.
+const testPrivateKey = "0x1b542690da83a0c3507e9e8c6ac03be689863d5241483b206a8f5ffd1fefd540"
+
+// newTestTransaction returns a transaction with every field set, sent by the test account.
+func newTestTransaction(t *testing.T) Transaction {
+	t.Helper()
+	sender, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	coin, err := NewStringStructTag(CedraCoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return Transaction{
+		Sender:         sender,
+		SequenceNumber: 7,
+		Payload: &TransactionPayload{
+			ModuleAddress: cedraFrameworkAddress,
+			ModuleName:    "cedra_account",
+			FunctionName:  "transfer",
+			TypeArguments: []TypeTag{coin, VectorTag{ElementType: U8Tag}},
+			Arguments:     [][]byte{{1, 2}, EncodeUintToBCS(uint64(5))},
+		},
+		FaAddress:                  coin,
+		MaxGasAmount:               1000,
+		GasUnitPrice:               100,
+		ExpirationTimestampSeconds: 123,
+		ChainId:                    uint8(TestnetChainID),
+	}
+}
+
+func TestBCSDecoderDecodeEnum(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    uint64
+		wantErr bool
+	}{
+		{name: "zero", data: []byte{0x00}, want: 0},
+		{name: "one byte max", data: []byte{0x7f}, want: 127},
+		{name: "two bytes", data: []byte{0x80, 0x01}, want: 128},
+		{name: "max uint64", data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, want: ^uint64(0)},
+		{name: "non-canonical trailing zero", data: []byte{0x80, 0x00}, wantErr: true},
+		{name: "non-canonical zero", data: []byte{0x80, 0x80, 0x00}, wantErr: true},
+		{name: "overflow", data: []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02}, wantErr: true},
+		{name: "too long", data: []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, wantErr: true},
+		{name: "truncated", data: []byte{0x80}, wantErr: true},
+		{name: "empty", data: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewBCSDecoder(tt.data).DecodeEnum()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DecodeEnum() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("DecodeEnum() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBCSDecoderRejectsMalformedInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		decode func(bcs *BCSDecoder) error
+	}{
+		{
+			name: "length over remaining",
+			data: []byte{0x03, 'a', 'b'},
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.DecodeBytes()
+				return err
+			},
+		},
+		{
+			name: "huge length",
+			data: []byte{0xff, 0xff, 0xff, 0xff, 0x0f},
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.DecodeString()
+				return err
+			},
+		},
+		{
+			name: "truncated u64",
+			data: []byte{1, 2, 3},
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.DecodeUint64()
+				return err
+			},
+		},
+		{
+			name: "truncated address",
+			data: make([]byte, 31),
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.DecodeAddress()
+				return err
+			},
+		},
+		{
+			name: "invalid bool",
+			data: []byte{2},
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.DecodeBool()
+				return err
+			},
+		},
+		{
+			name: "negative raw read",
+			data: []byte{1},
+			decode: func(bcs *BCSDecoder) error {
+				_, err := bcs.ReadRawBytes(-1)
+				return err
+			},
+		},
+		{
+			name: "trailing bytes",
+			data: []byte{0x01, 0x00},
+			decode: func(bcs *BCSDecoder) error {
+				if _, err := bcs.DecodeUint8(); err != nil {
+					return err
+				}
+				return bcs.Finish()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.decode(NewBCSDecoder(tt.data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestBCSDecoderReadsEncodedValues(t *testing.T) {
+	bcs := NewBCSEncoder()
+	bcs.EncodeEnum(300)
+	bcs.EncodeString("cedra")
+	bcs.EncodeBytes([]byte{0xca, 0xfe})
+	bcs.WriteRawBytes(EncodeUintToBCS(uint64(1 << 40)))
+	bcs.WriteRawBytes(cedraFrameworkAddress[:])
+
+	decoder := NewBCSDecoder(bcs.GetBytes())
+	enum, err := decoder.DecodeEnum()
+	if err != nil || enum != 300 {
+		t.Fatalf("DecodeEnum() = %d, %v", enum, err)
+	}
+	value, err := decoder.DecodeString()
+	if err != nil || value != "cedra" {
+		t.Fatalf("DecodeString() = %q, %v", value, err)
+	}
+	data, err := decoder.DecodeBytes()
+	if err != nil || !bytes.Equal(data, []byte{0xca, 0xfe}) {
+		t.Fatalf("DecodeBytes() = %x, %v", data, err)
+	}
+	number, err := decoder.DecodeUint64()
+	if err != nil || number != 1<<40 {
+		t.Fatalf("DecodeUint64() = %d, %v", number, err)
+	}
+	address, err := decoder.DecodeAddress()
+	if err != nil || address != cedraFrameworkAddress {
+		t.Fatalf("DecodeAddress() = %x, %v", address, err)
+	}
+	if err := decoder.Finish(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDecodeTransactionRoundTrip(t *testing.T) {
+	tx := newTestTransaction(t)
+	encoded := tx.ToBCSBytes()
+
+	decoded, err := DecodeTransaction(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.ToBCSBytes(), encoded) {
+		t.Fatalf("decoded transaction encodes to %x, want %x", decoded.ToBCSBytes(), encoded)
+	}
+	if decoded.Sender.GetAccountAddress() != tx.Sender.GetAccountAddress() {
+		t.Fatal("sender address mismatch")
+	}
+
+	if _, err := DecodeTransaction(append(encoded, 0)); err == nil {
+		t.Fatal("expected an error for trailing bytes")
+	}
+	if _, err := DecodeTransaction(encoded[:len(encoded)-1]); err == nil {
+		t.Fatal("expected an error for truncated input")
+	}
+}
+
+func TestDecodeSignedTransactionRoundTrip(t *testing.T) {
+	tx := newTestTransaction(t)
+	encoded, auth, err := tx.Sign(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := EncodeSignedTransaction(encoded, auth)
+
+	decoded, decodedAuth, err := DecodeSignedTransaction(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.ToBCSBytes(), encoded) {
+		t.Fatal("transaction mismatch")
+	}
+	if !reflect.DeepEqual(decodedAuth, auth) {
+		t.Fatalf("authenticator = %+v, want %+v", decodedAuth, auth)
+	}
+
+	if _, _, err := DecodeSignedTransaction(append(signed, 0)); err == nil {
+		t.Fatal("expected an error for trailing bytes")
+	}
+	if _, _, err := DecodeSignedTransaction(encoded); err == nil {
+		t.Fatal("expected an error for a missing authenticator")
+	}
+}
@@ -0,0 +1,244 @@
+package cedra
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Int128 is a Move i128 value stored as 16 little-endian two's complement bytes, exactly as it is encoded in BCS.
+type Int128 [16]byte
+
+// Int256 is a Move i256 value stored as 32 little-endian two's complement bytes, exactly as it is encoded in BCS.
+type Int256 [32]byte
+
+// NewInt128 converts a big integer into an Int128.
+// Returns an error if the value is nil or doesn't fit into the signed 128-bit range.
+func NewInt128(value *big.Int) (Int128, error) {
+	var i Int128
+	if err := putIntLittleEndian(i[:], value); err != nil {
+		return Int128{}, errors.Wrap(err, "can't create i128")
+	}
+
+	return i, nil
+}
+
+// NewInt128FromInt64 converts an int64 into an Int128, sign-extending it to 128 bits.
+func NewInt128FromInt64(value int64) Int128 {
+	var i Int128
+	signExtend(i[:], value)
+
+	return i
+}
+
+// BigInt returns the value as a big integer.
+func (i Int128) BigInt() *big.Int {
+	return intFromLittleEndian(i[:])
+}
+
+// String returns the decimal representation of the value.
+func (i Int128) String() string {
+	return i.BigInt().String()
+}
+
+// ToBCSBytes encodes the value into Binary Canonical Serialization (BCS) format.
+func (i Int128) ToBCSBytes() []byte {
+	return i[:]
+}
+
+// UnmarshalBCS decodes the value from Binary Canonical Serialization (BCS) format.
+func (i *Int128) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeInt128()
+	if err != nil {
+		return err
+	}
+	*i = value
+
+	return nil
+}
+
+// NewInt256 converts a big integer into an Int256.
+// Returns an error if the value is nil or doesn't fit into the signed 256-bit range.
+func NewInt256(value *big.Int) (Int256, error) {
+	var i Int256
+	if err := putIntLittleEndian(i[:], value); err != nil {
+		return Int256{}, errors.Wrap(err, "can't create i256")
+	}
+
+	return i, nil
+}
+
+// NewInt256FromInt64 converts an int64 into an Int256, sign-extending it to 256 bits.
+func NewInt256FromInt64(value int64) Int256 {
+	var i Int256
+	signExtend(i[:], value)
+
+	return i
+}
+
+// BigInt returns the value as a big integer.
+func (i Int256) BigInt() *big.Int {
+	return intFromLittleEndian(i[:])
+}
+
+// String returns the decimal representation of the value.
+func (i Int256) String() string {
+	return i.BigInt().String()
+}
+
+// ToBCSBytes encodes the value into Binary Canonical Serialization (BCS) format.
+func (i Int256) ToBCSBytes() []byte {
+	return i[:]
+}
+
+// UnmarshalBCS decodes the value from Binary Canonical Serialization (BCS) format.
+func (i *Int256) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeInt256()
+	if err != nil {
+		return err
+	}
+	*i = value
+
+	return nil
+}
+
+// EncodeInt128ToBCS encodes a big integer as a Move i128 in BCS format.
+// Returns an error if the value is nil or doesn't fit into the signed 128-bit range.
+func EncodeInt128ToBCS(value *big.Int) ([]byte, error) {
+	i, err := NewInt128(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.ToBCSBytes(), nil
+}
+
+// EncodeInt256ToBCS encodes a big integer as a Move i256 in BCS format.
+// Returns an error if the value is nil or doesn't fit into the signed 256-bit range.
+func EncodeInt256ToBCS(value *big.Int) ([]byte, error) {
+	i, err := NewInt256(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return i.ToBCSBytes(), nil
+}
+
+// DecodeInt8 decodes a single byte signed integer.
+func (bcs *BCSDecoder) DecodeInt8() (int8, error) {
+	value, err := bcs.DecodeUint8()
+
+	return int8(value), err
+}
+
+// DecodeInt16 decodes a little-endian two's complement 16-bit signed integer.
+func (bcs *BCSDecoder) DecodeInt16() (int16, error) {
+	value, err := bcs.DecodeUint16()
+
+	return int16(value), err
+}
+
+// DecodeInt32 decodes a little-endian two's complement 32-bit signed integer.
+func (bcs *BCSDecoder) DecodeInt32() (int32, error) {
+	value, err := bcs.DecodeUint32()
+
+	return int32(value), err
+}
+
+// DecodeInt64 decodes a little-endian two's complement 64-bit signed integer.
+func (bcs *BCSDecoder) DecodeInt64() (int64, error) {
+	value, err := bcs.DecodeUint64()
+
+	return int64(value), err
+}
+
+// DecodeInt128 decodes a little-endian two's complement 128-bit signed integer.
+func (bcs *BCSDecoder) DecodeInt128() (Int128, error) {
+	buf, err := bcs.ReadRawBytes(16)
+	if err != nil {
+		return Int128{}, err
+	}
+
+	return Int128(buf), nil
+}
+
+// DecodeInt256 decodes a little-endian two's complement 256-bit signed integer.
+func (bcs *BCSDecoder) DecodeInt256() (Int256, error) {
+	buf, err := bcs.ReadRawBytes(32)
+	if err != nil {
+		return Int256{}, err
+	}
+
+	return Int256(buf), nil
+}
+
+// DecodeIntFromBCS decodes a little-endian two's complement signed integer produced by EncodeIntToBCS.
+// Returns an error if the input length doesn't match the size of the requested type.
+func DecodeIntFromBCS[Type int8 | int16 | int32 | int64 | Int128 | Int256](data []byte) (Type, error) {
+	var (
+		value   Type
+		decoded any
+		err     error
+	)
+	bcs := NewBCSDecoder(data)
+
+	switch any(value).(type) {
+	case int8:
+		decoded, err = bcs.DecodeInt8()
+	case int16:
+		decoded, err = bcs.DecodeInt16()
+	case int32:
+		decoded, err = bcs.DecodeInt32()
+	case int64:
+		decoded, err = bcs.DecodeInt64()
+	case Int128:
+		decoded, err = bcs.DecodeInt128()
+	case Int256:
+		decoded, err = bcs.DecodeInt256()
+	}
+	if err != nil {
+		return value, err
+	}
+
+	return decoded.(Type), bcs.Finish()
+}
+
+// putIntLittleEndian writes a big integer into buf as little-endian two's complement.
+func putIntLittleEndian(buf []byte, value *big.Int) error {
+	if value == nil {
+		return errors.New("value is nil")
+	}
+	bits := uint(len(buf) * 8)
+	limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	if value.Cmp(limit) >= 0 || value.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return errors.Errorf("value %s overflows signed %d bits", value, bits)
+	}
+
+	unsigned := new(big.Int).Set(value)
+	if unsigned.Sign() < 0 {
+		unsigned.Add(unsigned, new(big.Int).Lsh(big.NewInt(1), bits))
+	}
+
+	return putUintLittleEndian(buf, unsigned)
+}
+
+// intFromLittleEndian reads a big integer from little-endian two's complement bytes.
+func intFromLittleEndian(buf []byte) *big.Int {
+	value := uintFromLittleEndian(buf)
+	if len(buf) > 0 && buf[len(buf)-1]&0x80 != 0 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(len(buf)*8)))
+	}
+
+	return value
+}
+
+// signExtend writes an int64 into buf as little-endian two's complement, filling the upper bytes with the sign.
+func signExtend(buf []byte, value int64) {
+	binary.LittleEndian.PutUint64(buf, uint64(value))
+	if value < 0 {
+		for i := 8; i < len(buf); i++ {
+			buf[i] = 0xFF
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package cedra
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// intVector is a signed integer with its expected little-endian two's complement BCS encoding.
+type intVector[T int8 | int16 | int32 | int64 | Int128 | Int256] struct {
+	name  string
+	value T
+	hex   string
+}
+
+// testIntVectors checks that every value encodes to its golden vector and decodes back.
+func testIntVectors[T int8 | int16 | int32 | int64 | Int128 | Int256](t *testing.T, vectors []intVector[T]) {
+	t.Helper()
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			want, err := hex.DecodeString(v.hex)
+			if err != nil {
+				t.Fatal(err)
+			}
+			encoded := EncodeIntToBCS(v.value)
+			if !bytes.Equal(encoded, want) {
+				t.Fatalf("EncodeIntToBCS(%v) = %x, want %x", v.value, encoded, want)
+			}
+			decoded, err := DecodeIntFromBCS[T](encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if decoded != v.value {
+				t.Fatalf("DecodeIntFromBCS(%x) = %v, want %v", encoded, decoded, v.value)
+			}
+			if _, err := DecodeIntFromBCS[T](append(encoded, 0)); err == nil {
+				t.Fatal("expected an error for trailing bytes")
+			}
+			if _, err := DecodeIntFromBCS[T](encoded[1:]); err == nil {
+				t.Fatal("expected an error for truncated input")
+			}
+		})
+	}
+}
+
+func TestEncodeIntToBCSInt8(t *testing.T) {
+	testIntVectors(t, []intVector[int8]{
+		{name: "zero", value: 0, hex: "00"},
+		{name: "minus one", value: -1, hex: "ff"},
+		{name: "min", value: math.MinInt8, hex: "80"},
+		{name: "max", value: math.MaxInt8, hex: "7f"},
+	})
+}
+
+func TestEncodeIntToBCSInt16(t *testing.T) {
+	testIntVectors(t, []intVector[int16]{
+		{name: "zero", value: 0, hex: "0000"},
+		{name: "minus one", value: -1, hex: "ffff"},
+		{name: "min", value: math.MinInt16, hex: "0080"},
+		{name: "max", value: math.MaxInt16, hex: "ff7f"},
+		{name: "little endian", value: 0x0102, hex: "0201"},
+	})
+}
+
+func TestEncodeIntToBCSInt32(t *testing.T) {
+	testIntVectors(t, []intVector[int32]{
+		{name: "zero", value: 0, hex: "00000000"},
+		{name: "minus one", value: -1, hex: "ffffffff"},
+		{name: "min", value: math.MinInt32, hex: "00000080"},
+		{name: "max", value: math.MaxInt32, hex: "ffffff7f"},
+		{name: "little endian", value: 0x01020304, hex: "04030201"},
+	})
+}
+
+func TestEncodeIntToBCSInt64(t *testing.T) {
+	testIntVectors(t, []intVector[int64]{
+		{name: "zero", value: 0, hex: "0000000000000000"},
+		{name: "minus one", value: -1, hex: "ffffffffffffffff"},
+		{name: "min", value: math.MinInt64, hex: "0000000000000080"},
+		{name: "max", value: math.MaxInt64, hex: "ffffffffffffff7f"},
+		{name: "minus two", value: -2, hex: "feffffffffffffff"},
+	})
+}
+
+func TestEncodeIntToBCSInt128(t *testing.T) {
+	minValue, maxValue := signedBounds(128)
+	testIntVectors(t, []intVector[Int128]{
+		{name: "zero", value: mustInt128(t, big.NewInt(0)), hex: strings.Repeat("00", 16)},
+		{name: "minus one", value: NewInt128FromInt64(-1), hex: strings.Repeat("ff", 16)},
+		{name: "min", value: mustInt128(t, minValue), hex: strings.Repeat("00", 15) + "80"},
+		{name: "max", value: mustInt128(t, maxValue), hex: strings.Repeat("ff", 15) + "7f"},
+		{name: "min int64", value: NewInt128FromInt64(math.MinInt64), hex: strings.Repeat("00", 7) + "80" + strings.Repeat("ff", 8)},
+	})
+}
+
+func TestEncodeIntToBCSInt256(t *testing.T) {
+	minValue, maxValue := signedBounds(256)
+	testIntVectors(t, []intVector[Int256]{
+		{name: "zero", value: mustInt256(t, big.NewInt(0)), hex: strings.Repeat("00", 32)},
+		{name: "minus one", value: NewInt256FromInt64(-1), hex: strings.Repeat("ff", 32)},
+		{name: "min", value: mustInt256(t, minValue), hex: strings.Repeat("00", 31) + "80"},
+		{name: "max", value: mustInt256(t, maxValue), hex: strings.Repeat("ff", 31) + "7f"},
+		{name: "one", value: NewInt256FromInt64(1), hex: "01" + strings.Repeat("00", 31)},
+	})
+}
+
+func TestNewIntOverflow(t *testing.T) {
+	min128, max128 := signedBounds(128)
+	min256, max256 := signedBounds(256)
+	one := big.NewInt(1)
+
+	tests := []struct {
+		name   string
+		encode func(*big.Int) ([]byte, error)
+		value  *big.Int
+	}{
+		{name: "i128 max+1", encode: EncodeInt128ToBCS, value: new(big.Int).Add(max128, one)},
+		{name: "i128 min-1", encode: EncodeInt128ToBCS, value: new(big.Int).Sub(min128, one)},
+		{name: "i128 nil", encode: EncodeInt128ToBCS, value: nil},
+		{name: "i256 max+1", encode: EncodeInt256ToBCS, value: new(big.Int).Add(max256, one)},
+		{name: "i256 min-1", encode: EncodeInt256ToBCS, value: new(big.Int).Sub(min256, one)},
+		{name: "i256 nil", encode: EncodeInt256ToBCS, value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.encode(tt.value); err == nil {
+				t.Fatal("expected an overflow error")
+			}
+		})
+	}
+}
+
+func TestIntBigIntRoundTrip(t *testing.T) {
+	min128, max128 := signedBounds(128)
+	min256, max256 := signedBounds(256)
+	for _, value := range []*big.Int{big.NewInt(0), big.NewInt(-1), big.NewInt(math.MinInt64), min128, max128} {
+		if got := mustInt128(t, value).BigInt(); got.Cmp(value) != 0 {
+			t.Fatalf("Int128(%s).BigInt() = %s", value, got)
+		}
+	}
+	for _, value := range []*big.Int{big.NewInt(0), big.NewInt(-1), min256, max256} {
+		if got := mustInt256(t, value).BigInt(); got.Cmp(value) != 0 {
+			t.Fatalf("Int256(%s).BigInt() = %s", value, got)
+		}
+	}
+}
+
+// signedBounds returns the minimum and maximum values of a signed integer of the bit size.
+func signedBounds(bits uint) (*big.Int, *big.Int) {
+	limit := new(big.Int).Lsh(big.NewInt(1), bits-1)
+
+	return new(big.Int).Neg(limit), new(big.Int).Sub(limit, big.NewInt(1))
+}
+
+// mustInt128 converts the value into an Int128, failing the test on error.
+func mustInt128(t *testing.T, value *big.Int) Int128 {
+	t.Helper()
+	i, err := NewInt128(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return i
+}
+
+// mustInt256 converts the value into an Int256, failing the test on error.
+func mustInt256(t *testing.T, value *big.Int) Int256 {
+	t.Helper()
+	i, err := NewInt256(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return i
+}
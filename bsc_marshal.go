@@ -0,0 +1,546 @@
+package cedra
+
+import (
+	"math/big"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+const (
+	// bcsTagName is the struct tag key used to control BCS field serialization.
+	bcsTagName = "bcs"
+	// bcsTagSkip marks a struct field that must not be serialized.
+	bcsTagSkip = "-"
+	// bcsTagU128 marks a big.Int or *big.Int struct field that is serialized as a Move u128.
+	bcsTagU128 = "u128"
+	// bcsTagU256 marks a big.Int or *big.Int struct field that is serialized as a Move u256.
+	bcsTagU256 = "u256"
+	// bcsTagI128 marks a big.Int or *big.Int struct field that is serialized as a Move i128.
+	bcsTagI128 = "i128"
+	// bcsTagI256 marks a big.Int or *big.Int struct field that is serialized as a Move i256.
+	bcsTagI256 = "i256"
+)
+
+// BCSMarshaler is implemented by types that encode themselves into BCS.
+// Marshal calls MarshalBCS instead of encoding the value by reflection.
+type BCSMarshaler interface {
+	MarshalBCS(bcs *BCSEncoder) error
+}
+
+// BCSUnmarshaler is implemented by types that decode themselves from BCS.
+// Unmarshal calls UnmarshalBCS instead of decoding the value by reflection.
+type BCSUnmarshaler interface {
+	UnmarshalBCS(bcs *BCSDecoder) error
+}
+
+// BCSEnum marks a struct as a BCS enum (a Move/Rust sum type).
+// Every serialized field of an enum struct must be a pointer, and exactly one of them must be non-nil.
+// The index of the non-nil field among the serialized fields is written as the ULEB128 variant,
+// followed by the value it points to.
+type BCSEnum interface {
+	IsBCSEnum()
+}
+
+// bcsBytesEncoder is implemented by the hand-written types of this package (StructTag, TransactionPayload, ...).
+type bcsBytesEncoder interface {
+	ToBCSBytes() []byte
+}
+
+var (
+	// bcsEnumType is the reflected BCSEnum interface type.
+	bcsEnumType = reflect.TypeFor[BCSEnum]()
+	// bigIntType is the reflected big.Int type, which needs a width tag to be serialized.
+	bigIntType = reflect.TypeFor[big.Int]()
+	// typeTagType is the reflected TypeTag interface type.
+	typeTagType = reflect.TypeFor[TypeTag]()
+	// payloadType is the reflected Payload interface type.
+	payloadType = reflect.TypeFor[Payload]()
+
+	// bigIntEncoders maps the big.Int width tags to their encoders.
+	bigIntEncoders = map[string]func(*big.Int) ([]byte, error){
+		bcsTagU128: EncodeUint128ToBCS,
+		bcsTagU256: EncodeUint256ToBCS,
+		bcsTagI128: EncodeInt128ToBCS,
+		bcsTagI256: EncodeInt256ToBCS,
+	}
+	// bigIntDecoders maps the big.Int width tags to their decoders.
+	bigIntDecoders = map[string]func(*BCSDecoder) (*big.Int, error){
+		bcsTagU128: func(bcs *BCSDecoder) (*big.Int, error) {
+			value, err := bcs.DecodeUint128()
+			return value.BigInt(), err
+		},
+		bcsTagU256: func(bcs *BCSDecoder) (*big.Int, error) {
+			value, err := bcs.DecodeUint256()
+			return value.BigInt(), err
+		},
+		bcsTagI128: func(bcs *BCSDecoder) (*big.Int, error) {
+			value, err := bcs.DecodeInt128()
+			return value.BigInt(), err
+		},
+		bcsTagI256: func(bcs *BCSDecoder) (*big.Int, error) {
+			value, err := bcs.DecodeInt256()
+			return value.BigInt(), err
+		},
+	}
+)
+
+// Marshal encodes a Go value into BCS using reflection.
+//
+// Booleans, fixed-width integers, strings, slices (vector<T>), arrays (fixed-length sequences),
+// structs (fields in declaration order) and pointers (Option<T>) are supported.
+// Struct fields tagged with `bcs:"-"` are skipped, and big.Int or *big.Int fields must be tagged
+// with `bcs:"u128"`, `bcs:"u256"`, `bcs:"i128"` or `bcs:"i256"`; a nil *big.Int is an error, not None.
+// Types implementing BCSMarshaler or providing a ToBCSBytes method are encoded by that method,
+// and structs implementing BCSEnum are encoded as enums.
+// Platform-dependent int/uint, floats, maps and channels are rejected.
+func Marshal(value any) ([]byte, error) {
+	bcs := NewBCSEncoder()
+	if err := bcs.EncodeValue(value); err != nil {
+		return nil, err
+	}
+
+	return bcs.GetBytes(), nil
+}
+
+// Unmarshal decodes BCS data into the value pointed to by target using reflection.
+// It follows the same rules as Marshal and returns an error if the data contains trailing bytes.
+// Types providing ToBCSBytes are decoded by their UnmarshalBCS method, and TypeTag and Payload
+// interface fields by their variant.
+func Unmarshal(data []byte, target any) error {
+	bcs := NewBCSDecoder(data)
+	if err := bcs.DecodeValue(target); err != nil {
+		return err
+	}
+
+	return bcs.Finish()
+}
+
+// EncodeValue appends the BCS encoding of an arbitrary Go value to the encoder.
+// A top-level pointer is dereferenced rather than encoded as an Option. See Marshal for the supported types.
+func (bcs *BCSEncoder) EncodeValue(value any) error {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return errors.Errorf("can't marshal bcs value: nil %T", value)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return errors.New("can't marshal bcs value: nil value")
+	}
+
+	return bcs.encodeReflect(addressable(rv))
+}
+
+// DecodeValue decodes the next value from the decoder into the value pointed to by target.
+// See Marshal for the supported types.
+func (bcs *BCSDecoder) DecodeValue(target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.Errorf("can't unmarshal bcs value: target must be a non-nil pointer, got %T", target)
+	}
+
+	return bcs.decodeReflect(rv.Elem())
+}
+
+func (bcs *BCSEncoder) encodeReflect(rv reflect.Value) error {
+	// A pointer is an Option even if it implements one of the interfaces; an interface value
+	// holding a pointer (e.g. a Payload) is encoded by the methods of the pointer.
+	if rv.Kind() != reflect.Pointer && (rv.Kind() != reflect.Interface || !rv.IsNil()) {
+		if marshaler, ok := asInterface[BCSMarshaler](rv); ok {
+			return marshaler.MarshalBCS(bcs)
+		}
+		if encoder, ok := asInterface[bcsBytesEncoder](rv); ok {
+			bcs.WriteRawBytes(encoder.ToBCSBytes())
+
+			return nil
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Interface:
+		if rv.IsNil() {
+			return errors.Errorf("can't marshal bcs value: nil %s", rv.Type())
+		}
+
+		return bcs.encodeReflect(addressable(rv.Elem()))
+	case reflect.Pointer:
+		if rv.IsNil() {
+			bcs.WriteRawBytes([]byte{0})
+
+			return nil
+		}
+		bcs.WriteRawBytes([]byte{1})
+
+		return bcs.encodeReflect(rv.Elem())
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			bcs.WriteRawBytes([]byte{1})
+		} else {
+			bcs.WriteRawBytes([]byte{0})
+		}
+	case reflect.Uint8:
+		bcs.WriteRawBytes(EncodeUintToBCS(uint8(rv.Uint())))
+	case reflect.Uint16:
+		bcs.WriteRawBytes(EncodeUintToBCS(uint16(rv.Uint())))
+	case reflect.Uint32:
+		bcs.WriteRawBytes(EncodeUintToBCS(uint32(rv.Uint())))
+	case reflect.Uint64:
+		bcs.WriteRawBytes(EncodeUintToBCS(rv.Uint()))
+	case reflect.Int8:
+		bcs.WriteRawBytes(EncodeIntToBCS(int8(rv.Int())))
+	case reflect.Int16:
+		bcs.WriteRawBytes(EncodeIntToBCS(int16(rv.Int())))
+	case reflect.Int32:
+		bcs.WriteRawBytes(EncodeIntToBCS(int32(rv.Int())))
+	case reflect.Int64:
+		bcs.WriteRawBytes(EncodeIntToBCS(rv.Int()))
+	case reflect.String:
+		bcs.EncodeString(rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			bcs.EncodeBytes(rv.Bytes())
+
+			return nil
+		}
+		bcs.EncodeEnum(cast.ToUint64(rv.Len()))
+
+		return bcs.encodeSequence(rv)
+	case reflect.Array:
+		return bcs.encodeSequence(rv)
+	case reflect.Struct:
+		if rv.Type() == bigIntType {
+			return errors.New("can't marshal bcs value: big.Int field requires a u128, u256, i128 or i256 tag")
+		}
+		if rv.Type().Implements(bcsEnumType) || reflect.PointerTo(rv.Type()).Implements(bcsEnumType) {
+			return bcs.encodeEnumStruct(rv)
+		}
+
+		return bcs.encodeStruct(rv)
+	default:
+		return errors.Errorf("can't marshal bcs value: unsupported type %s", rv.Type())
+	}
+
+	return nil
+}
+
+func (bcs *BCSEncoder) encodeSequence(rv reflect.Value) error {
+	for i := 0; i < rv.Len(); i++ {
+		if err := bcs.encodeReflect(rv.Index(i)); err != nil {
+			return errors.Wrapf(err, "element %d", i)
+		}
+	}
+
+	return nil
+}
+
+func (bcs *BCSEncoder) encodeStruct(rv reflect.Value) error {
+	for _, i := range bcsFields(rv.Type()) {
+		if err := bcs.encodeField(rv.Field(i), rv.Type().Field(i).Tag.Get(bcsTagName)); err != nil {
+			return errors.Wrapf(err, "%s.%s", rv.Type().Name(), rv.Type().Field(i).Name)
+		}
+	}
+
+	return nil
+}
+
+func (bcs *BCSEncoder) encodeField(rv reflect.Value, tag string) error {
+	encode, ok := bigIntEncoders[tag]
+	if !ok {
+		return bcs.encodeReflect(rv)
+	}
+
+	var value *big.Int
+	switch {
+	case rv.Type() == bigIntType:
+		value = rv.Addr().Interface().(*big.Int)
+	case rv.Type() == reflect.PointerTo(bigIntType):
+		value = rv.Interface().(*big.Int)
+	default:
+		return errors.Errorf("can't marshal bcs value: %s tag requires a big.Int field, got %s", tag, rv.Type())
+	}
+
+	encoded, err := encode(value)
+	if err != nil {
+		return err
+	}
+	bcs.WriteRawBytes(encoded)
+
+	return nil
+}
+
+func (bcs *BCSEncoder) encodeEnumStruct(rv reflect.Value) error {
+	variant := -1
+	for idx, i := range bcsFields(rv.Type()) {
+		field := rv.Field(i)
+		if field.Kind() != reflect.Pointer {
+			return errors.Errorf("can't marshal bcs enum %s: field %s must be a pointer", rv.Type(), rv.Type().Field(i).Name)
+		}
+		if field.IsNil() {
+			continue
+		}
+		if variant != -1 {
+			return errors.Errorf("can't marshal bcs enum %s: more than one variant is set", rv.Type())
+		}
+		variant = idx
+		bcs.EncodeEnum(cast.ToUint64(idx))
+		if err := bcs.encodeReflect(field.Elem()); err != nil {
+			return errors.Wrapf(err, "%s.%s", rv.Type().Name(), rv.Type().Field(i).Name)
+		}
+	}
+	if variant == -1 {
+		return errors.Errorf("can't marshal bcs enum %s: no variant is set", rv.Type())
+	}
+
+	return nil
+}
+
+func (bcs *BCSDecoder) decodeReflect(rv reflect.Value) error {
+	if rv.Kind() == reflect.Interface {
+		return bcs.decodeInterface(rv)
+	}
+	if rv.Kind() != reflect.Pointer {
+		if unmarshaler, ok := asInterface[BCSUnmarshaler](rv); ok {
+			return unmarshaler.UnmarshalBCS(bcs)
+		}
+		// The BCS form produced by ToBCSBytes differs from the reflected layout of the type.
+		if _, ok := asInterface[bcsBytesEncoder](rv); ok {
+			return errors.Errorf("can't unmarshal bcs value: %s has no UnmarshalBCS method", rv.Type())
+		}
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		value, err := bcs.DecodeBool()
+		if err != nil {
+			return err
+		}
+		rv.SetBool(value)
+	case reflect.Uint8:
+		value, err := bcs.DecodeUint8()
+		if err != nil {
+			return err
+		}
+		rv.SetUint(uint64(value))
+	case reflect.Uint16:
+		value, err := bcs.DecodeUint16()
+		if err != nil {
+			return err
+		}
+		rv.SetUint(uint64(value))
+	case reflect.Uint32:
+		value, err := bcs.DecodeUint32()
+		if err != nil {
+			return err
+		}
+		rv.SetUint(uint64(value))
+	case reflect.Uint64:
+		value, err := bcs.DecodeUint64()
+		if err != nil {
+			return err
+		}
+		rv.SetUint(value)
+	case reflect.Int8:
+		value, err := bcs.DecodeInt8()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(value))
+	case reflect.Int16:
+		value, err := bcs.DecodeInt16()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(value))
+	case reflect.Int32:
+		value, err := bcs.DecodeInt32()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(value))
+	case reflect.Int64:
+		value, err := bcs.DecodeInt64()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(value)
+	case reflect.String:
+		value, err := bcs.DecodeString()
+		if err != nil {
+			return err
+		}
+		rv.SetString(value)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			value, err := bcs.DecodeBytes()
+			if err != nil {
+				return err
+			}
+			rv.SetBytes(value)
+
+			return nil
+		}
+		length, err := bcs.DecodeLength()
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.MakeSlice(rv.Type(), length, length))
+
+		return bcs.decodeSequence(rv)
+	case reflect.Array:
+		return bcs.decodeSequence(rv)
+	case reflect.Pointer:
+		isSet, err := bcs.DecodeBool()
+		if err != nil {
+			return errors.Wrap(err, "option tag")
+		}
+		if !isSet {
+			rv.SetZero()
+
+			return nil
+		}
+		rv.Set(reflect.New(rv.Type().Elem()))
+
+		return bcs.decodeReflect(rv.Elem())
+	case reflect.Struct:
+		if rv.Type() == bigIntType {
+			return errors.New("can't unmarshal bcs value: big.Int field requires a u128, u256, i128 or i256 tag")
+		}
+		if rv.Type().Implements(bcsEnumType) || reflect.PointerTo(rv.Type()).Implements(bcsEnumType) {
+			return bcs.decodeEnumStruct(rv)
+		}
+
+		return bcs.decodeStruct(rv)
+	default:
+		return errors.Errorf("can't unmarshal bcs value: unsupported type %s", rv.Type())
+	}
+
+	return nil
+}
+
+// decodeInterface decodes the interface types of this package whose variants are known: TypeTag and Payload.
+func (bcs *BCSDecoder) decodeInterface(rv reflect.Value) error {
+	var (
+		value any
+		err   error
+	)
+	switch rv.Type() {
+	case typeTagType:
+		value, err = decodeTypeTag(bcs, 0)
+	case payloadType:
+		value, err = decodePayload(bcs)
+	default:
+		return errors.Errorf("can't unmarshal bcs value: unsupported interface type %s", rv.Type())
+	}
+	if err != nil {
+		return err
+	}
+	rv.Set(reflect.ValueOf(value))
+
+	return nil
+}
+
+func (bcs *BCSDecoder) decodeSequence(rv reflect.Value) error {
+	for i := 0; i < rv.Len(); i++ {
+		if err := bcs.decodeReflect(rv.Index(i)); err != nil {
+			return errors.Wrapf(err, "element %d", i)
+		}
+	}
+
+	return nil
+}
+
+func (bcs *BCSDecoder) decodeStruct(rv reflect.Value) error {
+	for _, i := range bcsFields(rv.Type()) {
+		if err := bcs.decodeField(rv.Field(i), rv.Type().Field(i).Tag.Get(bcsTagName)); err != nil {
+			return errors.Wrapf(err, "%s.%s", rv.Type().Name(), rv.Type().Field(i).Name)
+		}
+	}
+
+	return nil
+}
+
+func (bcs *BCSDecoder) decodeField(rv reflect.Value, tag string) error {
+	decode, ok := bigIntDecoders[tag]
+	if !ok {
+		return bcs.decodeReflect(rv)
+	}
+
+	value, err := decode(bcs)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case rv.Type() == bigIntType:
+		rv.Set(reflect.ValueOf(value).Elem())
+	case rv.Type() == reflect.PointerTo(bigIntType):
+		rv.Set(reflect.ValueOf(value))
+	default:
+		return errors.Errorf("can't unmarshal bcs value: %s tag requires a big.Int field, got %s", tag, rv.Type())
+	}
+
+	return nil
+}
+
+func (bcs *BCSDecoder) decodeEnumStruct(rv reflect.Value) error {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return errors.Wrapf(err, "%s variant", rv.Type().Name())
+	}
+	fields := bcsFields(rv.Type())
+	if variant >= cast.ToUint64(len(fields)) {
+		return errors.Errorf("can't unmarshal bcs enum %s: unknown variant %d", rv.Type(), variant)
+	}
+
+	rv.SetZero()
+	field := rv.Field(fields[variant])
+	if field.Kind() != reflect.Pointer {
+		return errors.Errorf("can't unmarshal bcs enum %s: field %s must be a pointer", rv.Type(), rv.Type().Field(fields[variant]).Name)
+	}
+	field.Set(reflect.New(field.Type().Elem()))
+
+	return bcs.decodeReflect(field.Elem())
+}
+
+// bcsFields returns the indexes of the exported struct fields that take part in BCS serialization.
+func bcsFields(t reflect.Type) []int {
+	fields := make([]int, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Tag.Get(bcsTagName) == bcsTagSkip {
+			continue
+		}
+		fields = append(fields, i)
+	}
+
+	return fields
+}
+
+// asInterface returns the value, or a pointer to it when addressable, as an implementation of I.
+func asInterface[I any](rv reflect.Value) (I, bool) {
+	if rv.CanAddr() {
+		if value, ok := rv.Addr().Interface().(I); ok {
+			return value, true
+		}
+	}
+	value, ok := rv.Interface().(I)
+
+	return value, ok
+}
+
+// addressable returns an addressable copy of the value so that pointer-receiver methods can be found.
+func addressable(rv reflect.Value) reflect.Value {
+	if rv.CanAddr() {
+		return rv
+	}
+	ptr := reflect.New(rv.Type())
+	ptr.Elem().Set(rv)
+
+	return ptr.Elem()
+}
@@ -0,0 +1,241 @@
+package cedra
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// testInner is a nested struct of testRecord.
+type testInner struct {
+	Flag  bool
+	Items []uint16
+}
+
+// testShape is a BCS enum with a unit-like and a struct variant.
+type testShape struct {
+	Point  *struct{}
+	Circle *testInner
+	// Cached is not serialized and does not count as a variant.
+	Cached string `bcs:"-"`
+}
+
+// IsBCSEnum implements BCSEnum.
+func (testShape) IsBCSEnum() {}
+
+// testEncodeOnly has a hand-written encoding but no UnmarshalBCS method.
+type testEncodeOnly struct {
+	Value uint8
+}
+
+// ToBCSBytes encodes the value as a byte vector.
+func (v testEncodeOnly) ToBCSBytes() []byte {
+	return EncodeToBCSBytes([]byte{v.Value})
+}
+
+// testRecord covers the struct features supported by Marshal.
+type testRecord struct {
+	Small   uint8
+	Number  uint64
+	Signed  int32
+	Name    string
+	Data    []byte
+	Address [32]byte
+	Inner   testInner
+	Option  *uint32
+	None    *string
+	Amount  *big.Int `bcs:"u128"`
+	Shape   testShape
+	Skipped int `bcs:"-"`
+	private int
+}
+
+func TestMarshalRoundTrip(t *testing.T) {
+	option := uint32(9)
+	value := testRecord{
+		Small:   1,
+		Number:  0x0102030405060708,
+		Signed:  -2,
+		Name:    "cedra",
+		Data:    []byte{0xca, 0xfe},
+		Address: cedraFrameworkAddress,
+		Inner:   testInner{Flag: true, Items: []uint16{1, 0x0203}},
+		Option:  &option,
+		Amount:  new(big.Int).Lsh(big.NewInt(1), 100),
+		Shape:   testShape{Circle: &testInner{Items: []uint16{}}},
+	}
+
+	data, err := Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{0x01}
+	want = append(want, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01)
+	want = append(want, 0xfe, 0xff, 0xff, 0xff)
+	want = append(want, 0x05, 'c', 'e', 'd', 'r', 'a')
+	want = append(want, 0x02, 0xca, 0xfe)
+	want = append(want, cedraFrameworkAddress[:]...)
+	want = append(want, 0x01, 0x02, 0x01, 0x00, 0x03, 0x02)
+	want = append(want, 0x01, 0x09, 0x00, 0x00, 0x00)
+	want = append(want, 0x00)
+	want = append(want, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0, 0)
+	want = append(want, 0x01, 0x00, 0x00)
+	if !bytes.Equal(data, want) {
+		t.Fatalf("Marshal() = %x, want %x", data, want)
+	}
+
+	var decoded testRecord
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, value) {
+		t.Fatalf("Unmarshal() = %+v, want %+v", decoded, value)
+	}
+}
+
+func TestMarshalSkipsUntaggedFields(t *testing.T) {
+	data, err := Marshal(testRecord{Skipped: 5, private: 6, Shape: testShape{Point: &struct{}{}, Cached: "x"}, Amount: big.NewInt(0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded testRecord
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Skipped != 0 || decoded.private != 0 || decoded.Shape.Cached != "" {
+		t.Fatalf("skipped fields were decoded: %+v", decoded)
+	}
+	if decoded.Shape.Point == nil || decoded.Shape.Circle != nil {
+		t.Fatalf("Shape = %+v, want the Point variant", decoded.Shape)
+	}
+}
+
+func TestUnmarshalRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		target any
+	}{
+		{name: "trailing bytes", data: []byte{1, 2}, target: new(uint8)},
+		{name: "invalid option tag", data: []byte{2}, target: new(*uint8)},
+		{name: "unknown enum variant", data: []byte{2}, target: new(testShape)},
+		{name: "unsupported type", data: []byte{1}, target: new(int)},
+		{name: "not a pointer", data: []byte{1}, target: uint8(0)},
+		{name: "encoder without unmarshaler", data: []byte{1}, target: new(testEncodeOnly)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal(tt.data, tt.target); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTripsPackageTypes(t *testing.T) {
+	coin, err := NewStringStructTag("0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	u128, err := NewUint128(big.NewInt(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	u256, err := NewUint256(new(big.Int).Lsh(big.NewInt(1), 200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	i128, err := NewInt128(big.NewInt(-1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{name: "struct tag", value: &coin},
+		{name: "primitive type tag", value: ptr(U64Tag)},
+		{name: "vector type tag", value: &VectorTag{ElementType: coin}},
+		{name: "entry function payload", value: tx.Payload.(*TransactionPayload)},
+		{name: "script payload", value: &ScriptPayload{
+			Code:          []byte{0xa1, 0x1c},
+			TypeArguments: []TypeTag{U8Tag},
+			Arguments:     []TransactionArgument{NewU64Argument(3), NewBoolArgument(true)},
+		}},
+		{name: "multisig payload", value: &MultisigPayload{MultisigAddress: cedraFrameworkAddress}},
+		{name: "public key", value: &AnyPublicKey{Variant: anyKeyEd25519Variant, PKey: make([]byte, 32)}},
+		{name: "fee payer transaction", value: NewFeePayerTransaction(&tx, cedraFrameworkAddress)},
+		{name: "multi-agent transaction", value: NewMultiAgentTransaction(&tx, cedraFrameworkAddress, [32]byte{2})},
+		{name: "sequence number", value: ptr(SequenceNumber(42))},
+		{name: "u128", value: &u128},
+		{name: "u256", value: &u256},
+		{name: "i128", value: &i128},
+		{name: "i256", value: ptr(NewInt256FromInt64(-5))},
+		{name: "struct with struct tag fields", value: &struct {
+			Coin   StructTag
+			Option *StructTag
+			Max    MaxGasAmount
+			Price  GasUnitPrice
+		}{Coin: coin, Option: &coin, Max: 1000, Price: 100}},
+		{name: "struct with type tag fields", value: &struct {
+			Tag  TypeTag
+			Tags []TypeTag
+		}{Tag: coin, Tags: []TypeTag{U8Tag, VectorTag{ElementType: AddressTag}}}},
+		{name: "struct with interface fields", value: &struct {
+			Tag     TypeTag
+			Payload Payload
+			Coin    *StructTag
+		}{Tag: coin, Payload: tx.Payload, Coin: &coin}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(tt.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			decoded := reflect.New(reflect.TypeOf(tt.value).Elem())
+			if err := Unmarshal(data, decoded.Interface()); err != nil {
+				t.Fatal(err)
+			}
+			// Empty slices are decoded as non-nil, so compare the encodings.
+			encoded, err := Marshal(decoded.Interface())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(encoded, data) {
+				t.Fatalf("Unmarshal() = %+v, encoded as %x, want %x", decoded.Elem(), encoded, data)
+			}
+		})
+	}
+}
+
+func TestMarshalRoundTripsTransaction(t *testing.T) {
+	tx := newTestTransaction(t)
+	data, err := Marshal(&tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, tx.ToBCSBytes()) {
+		t.Fatal("Marshal() doesn't match ToBCSBytes()")
+	}
+
+	var decoded Transaction
+	if err := Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.ToBCSBytes(), data) {
+		t.Fatal("decoded transaction doesn't encode to the same bytes")
+	}
+}
+
+// ptr returns a pointer to the value.
+func ptr[T any](value T) *T {
+	return &value
+}
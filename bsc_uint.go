@@ -0,0 +1,171 @@
+package cedra
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+// Uint128 is a Move u128 value stored as 16 little-endian bytes, exactly as it is encoded in BCS.
+type Uint128 [16]byte
+
+// Uint256 is a Move u256 value stored as 32 little-endian bytes, exactly as it is encoded in BCS.
+type Uint256 [32]byte
+
+// NewUint128 converts a big integer into a Uint128.
+// Returns an error if the value is nil, negative or doesn't fit into 128 bits.
+func NewUint128(value *big.Int) (Uint128, error) {
+	var u Uint128
+	if err := putUintLittleEndian(u[:], value); err != nil {
+		return Uint128{}, errors.Wrap(err, "can't create u128")
+	}
+
+	return u, nil
+}
+
+// NewUint128FromUint64 converts a uint64 into a Uint128.
+func NewUint128FromUint64(value uint64) Uint128 {
+	var u Uint128
+	copy(u[:], EncodeUintToBCS(value))
+
+	return u
+}
+
+// BigInt returns the value as a big integer.
+func (u Uint128) BigInt() *big.Int {
+	return uintFromLittleEndian(u[:])
+}
+
+// String returns the decimal representation of the value.
+func (u Uint128) String() string {
+	return u.BigInt().String()
+}
+
+// ToBCSBytes encodes the value into Binary Canonical Serialization (BCS) format.
+func (u Uint128) ToBCSBytes() []byte {
+	return u[:]
+}
+
+// UnmarshalBCS decodes the value from Binary Canonical Serialization (BCS) format.
+func (u *Uint128) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeUint128()
+	if err != nil {
+		return err
+	}
+	*u = value
+
+	return nil
+}
+
+// NewUint256 converts a big integer into a Uint256.
+// Returns an error if the value is nil, negative or doesn't fit into 256 bits.
+func NewUint256(value *big.Int) (Uint256, error) {
+	var u Uint256
+	if err := putUintLittleEndian(u[:], value); err != nil {
+		return Uint256{}, errors.Wrap(err, "can't create u256")
+	}
+
+	return u, nil
+}
+
+// NewUint256FromUint64 converts a uint64 into a Uint256.
+func NewUint256FromUint64(value uint64) Uint256 {
+	var u Uint256
+	copy(u[:], EncodeUintToBCS(value))
+
+	return u
+}
+
+// BigInt returns the value as a big integer.
+func (u Uint256) BigInt() *big.Int {
+	return uintFromLittleEndian(u[:])
+}
+
+// String returns the decimal representation of the value.
+func (u Uint256) String() string {
+	return u.BigInt().String()
+}
+
+// ToBCSBytes encodes the value into Binary Canonical Serialization (BCS) format.
+func (u Uint256) ToBCSBytes() []byte {
+	return u[:]
+}
+
+// UnmarshalBCS decodes the value from Binary Canonical Serialization (BCS) format.
+func (u *Uint256) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeUint256()
+	if err != nil {
+		return err
+	}
+	*u = value
+
+	return nil
+}
+
+// EncodeUint128ToBCS encodes a big integer as a Move u128 in BCS format.
+// Returns an error if the value is nil, negative or doesn't fit into 128 bits.
+func EncodeUint128ToBCS(value *big.Int) ([]byte, error) {
+	u, err := NewUint128(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.ToBCSBytes(), nil
+}
+
+// EncodeUint256ToBCS encodes a big integer as a Move u256 in BCS format.
+// Returns an error if the value is nil, negative or doesn't fit into 256 bits.
+func EncodeUint256ToBCS(value *big.Int) ([]byte, error) {
+	u, err := NewUint256(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.ToBCSBytes(), nil
+}
+
+// DecodeUint128 decodes a little-endian 128-bit unsigned integer.
+func (bcs *BCSDecoder) DecodeUint128() (Uint128, error) {
+	buf, err := bcs.ReadRawBytes(16)
+	if err != nil {
+		return Uint128{}, err
+	}
+
+	return Uint128(buf), nil
+}
+
+// DecodeUint256 decodes a little-endian 256-bit unsigned integer.
+func (bcs *BCSDecoder) DecodeUint256() (Uint256, error) {
+	buf, err := bcs.ReadRawBytes(32)
+	if err != nil {
+		return Uint256{}, err
+	}
+
+	return Uint256(buf), nil
+}
+
+// putUintLittleEndian writes a non-negative big integer into buf using little-endian byte order.
+func putUintLittleEndian(buf []byte, value *big.Int) error {
+	if value == nil {
+		return errors.New("value is nil")
+	}
+	if value.Sign() < 0 {
+		return errors.Errorf("negative value %s", value)
+	}
+	if value.BitLen() > len(buf)*8 {
+		return errors.Errorf("value %s overflows %d bits", value, len(buf)*8)
+	}
+	value.FillBytes(buf)
+	slices.Reverse(buf)
+
+	return nil
+}
+
+// uintFromLittleEndian reads a non-negative big integer from little-endian bytes.
+func uintFromLittleEndian(buf []byte) *big.Int {
+	bigEndian := slices.Clone(buf)
+	slices.Reverse(bigEndian)
+
+	return new(big.Int).SetBytes(bigEndian)
+}
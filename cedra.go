@@ -4,6 +4,9 @@ package cedra
 
 import (
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math"
 	"time"
 
 	"github.com/pkg/errors"
@@ -23,34 +26,64 @@ const (
 	pendingTx = "pending_transaction"
 )
 
+// cedraFrameworkAddress is the 32-byte form of CedraAddress, where the framework modules are published.
+var cedraFrameworkAddress = [32]byte{31: 1}
+
 // CedraClient is the main client for interacting with the Cedra blockchain.
 // It provides methods for creating and submitting transactions.
 type CedraClient struct {
-	// node is the Cedra node client used for network communication.
-	node CedraNode
+	// nodes is the pool of Cedra nodes used for network communication.
+	nodes *NodePool
 	// chainID identifies the blockchain network (devnet, testnet, mainnet).
 	chainID ChainID
+	// errorMaps caches the error maps of the modules used to explain Move aborts.
+	errorMaps *errorMapCache
 }
 
 // NewCedraClient creates a new CedraClient instance for the specified chain.
-func NewCedraClient(chainID ChainID) CedraClient {
+// The options configure the node client, see NewCedraNode.
+// Returns an error if the node client can't be created.
+func NewCedraClient(chainID ChainID, options ...NodeOption) (CedraClient, error) {
+	node, err := NewCedraNode(chainID, options...)
+	if err != nil {
+		return CedraClient{}, errors.Wrap(err, "can't create a new instance of CedraClient")
+	}
+	pool, err := NewNodePool([]CedraNode{node})
+	if err != nil {
+		return CedraClient{}, errors.Wrap(err, "can't create a new instance of CedraClient")
+	}
+
+	return NewCedraClientWithPool(pool), nil
+}
+
+// NewCedraClientWithPool creates a new CedraClient instance using the nodes of the pool,
+// for failover between several endpoints of the pool's chain.
+func NewCedraClientWithPool(pool *NodePool) CedraClient {
 	return CedraClient{
-		node:    NewCedraNode(chainID),
-		chainID: chainID,
+		nodes:     pool,
+		chainID:   pool.ChainID(),
+		errorMaps: newErrorMapCache(),
 	}
 }
 
-// NewTransaction creates a new transaction with the provided sender and payload.
+// NewTransaction creates a new transaction with the provided sender signer and payload.
+// The payload can be an entry function call (*TransactionPayload), a script (*ScriptPayload)
+// or a multisig execution (*MultisigPayload).
 // It concurrently fetches the sequence number and gas price estimate from the network if not provided via options.
 // The transaction expiration is set to 5 minutes from creation time.
-// Options can include SequenceNumber and GasUnitPrice to skip network calls.
-// Returns an error if the sequence number cannot be fetched or if the struct tag is invalid.
-func (c CedraClient) NewTransaction(sender Account, payload *TransactionPayload, options ...any) (*Transaction, error) {
+// Options can include SequenceNumber and GasUnitPrice to skip network calls, MaxGasAmount to replace
+// the default max gas amount, and SimulatedGasMultiplier (at least 1) to derive the max gas amount from a simulation
+// instead. Returns an error if the options are invalid, if the sequence number cannot be fetched, if the struct tag
+// is invalid or if the simulation fails. The context cancels the network calls.
+func (c CedraClient) NewTransaction(ctx context.Context, sender Signer, payload Payload, options ...any) (*Transaction, error) {
 	var (
-		seqNumber  SequenceNumber
-		gasPrice   GasUnitPrice
-		needSeqNum = true
-		needGasEst = true
+		seqNumber     SequenceNumber
+		gasPrice      GasUnitPrice
+		maxGasAmount  = defaultMaxGasAmount
+		gasMultiplier SimulatedGasMultiplier
+		needSeqNum    = true
+		needGasEst    = true
+		hasMaxGas     bool
 	)
 
 	// Parse options
@@ -66,13 +99,32 @@ func (c CedraClient) NewTransaction(sender Account, payload *TransactionPayload,
 				gasPrice = opt
 				needGasEst = false
 			}
+		case MaxGasAmount:
+			hasMaxGas = true
+			if opt.ToUint64() != 0 {
+				maxGasAmount = opt
+			}
+		case SimulatedGasMultiplier:
+			// Also rejects NaN.
+			if !(opt >= 1) {
+				return nil, errors.Errorf("NewTransaction: simulated gas multiplier %v is below 1", float64(opt))
+			}
+			gasMultiplier = opt
 		default:
 			return nil, errors.Errorf("NewTransaction: unknown option type %T at index %d", option, i)
 		}
 	}
 
+	if hasMaxGas && gasMultiplier > 0 {
+		return nil, errors.New("NewTransaction: MaxGasAmount and SimulatedGasMultiplier are mutually exclusive")
+	}
+
 	expirationSeconds := cast.ToUint64(time.Now().Unix() + 300)
 
+	// Cancel the pending fetch if the other one fails
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	// Fetch sequence number and gas price concurrently if needed
 	type seqResult struct {
 		value SequenceNumber
@@ -89,7 +141,8 @@ func (c CedraClient) NewTransaction(sender Account, payload *TransactionPayload,
 	if needSeqNum {
 		seqChan = make(chan seqResult, 1)
 		go func() {
-			seqNum, err := c.GetSequenceNumber(sender.GetAccountAddressString())
+			senderAddress := sender.GetAccountAddress()
+			seqNum, err := c.GetSequenceNumber(ctx, hex.EncodeToString(senderAddress[:]))
 			seqChan <- seqResult{value: SequenceNumber(seqNum), err: err}
 		}()
 	}
@@ -97,7 +150,7 @@ func (c CedraClient) NewTransaction(sender Account, payload *TransactionPayload,
 	if needGasEst {
 		gasChan = make(chan gasResult, 1)
 		go func() {
-			estimate, err := c.node.GetEstimateGasPrice()
+			estimate, err := c.nodes.GetEstimateGasPrice(ctx)
 			gasEstimate := GasUnitPrice(0)
 			if err == nil {
 				gasEstimate = GasUnitPrice(estimate.GasEstimate)
@@ -126,28 +179,37 @@ func (c CedraClient) NewTransaction(sender Account, payload *TransactionPayload,
 		return nil, errors.Wrap(err, "can't create new transaction: invalid struct tag")
 	}
 
-	return &Transaction{
+	tx := &Transaction{
 		Sender:                     sender,
 		SequenceNumber:             seqNumber,
-		Payload:                    *payload,
+		Payload:                    payload,
 		FaAddress:                  structTag,
 		GasUnitPrice:               gasPrice,
-		MaxGasAmount:               defaultMaxGasAmount,
+		MaxGasAmount:               maxGasAmount,
 		ExpirationTimestampSeconds: expirationSeconds,
 		ChainId:                    uint8(c.chainID),
-	}, nil
+	}
+
+	if gasMultiplier > 0 {
+		result, err := c.simulateTransaction(ctx, tx, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't create new transaction: failed to simulate transaction")
+		}
+		if !result.Success {
+			return nil, errors.Wrap(c.explainVMStatusError(ctx, ParseVMStatus(result.VMStatus)), "can't create new transaction: simulation failed")
+		}
+		tx.MaxGasAmount = MaxGasAmount(math.Ceil(float64(result.GasUsed) * float64(gasMultiplier)))
+	}
+
+	return tx, nil
 }
 
 // SubmitTransaction submits a signed transaction to the Cedra network.
 // The transaction bytes and authenticator are combined and sent to the node.
+// Use TransactionHash to know the hash of the transaction before submitting it.
 // Returns the transaction hash if successful, or an error if submission fails.
-func (c CedraClient) SubmitTransaction(tx []byte, auth CedraAuthenticator) (string, error) {
-	authBytes := auth.EncodeBSC()
-	signedTx := make([]byte, 0, len(tx)+len(authBytes))
-	signedTx = append(signedTx, tx...)
-	signedTx = append(signedTx, authBytes...)
-
-	hash, err := c.node.SubmitTransaction(signedTx)
+func (c CedraClient) SubmitTransaction(ctx context.Context, tx []byte, auth CedraAuthenticator) (string, error) {
+	hash, err := c.nodes.SubmitTransaction(ctx, EncodeSignedTransaction(tx, auth))
 	if err != nil {
 		return "", errors.Wrap(err, "can't submit transaction")
 	}
@@ -155,10 +217,41 @@ func (c CedraClient) SubmitTransaction(tx []byte, auth CedraAuthenticator) (stri
 	return hash, nil
 }
 
+// SimulateTransaction simulates the transaction on the network without submitting it.
+// The transaction is authenticated with the sender's public key and a zeroed signature,
+// so no signing is needed. Returns the simulated transaction with its gas used, VM status, events and state changes.
+func (c CedraClient) SimulateTransaction(ctx context.Context, tx *Transaction) (TransactionDTO, error) {
+	return c.simulateTransaction(ctx, tx, false)
+}
+
+// simulateTransaction simulates the transaction, letting the node pick the max gas amount if estimateMaxGasAmount is true.
+func (c CedraClient) simulateTransaction(ctx context.Context, tx *Transaction, estimateMaxGasAmount bool) (TransactionDTO, error) {
+	auth := tx.Sender.NewAuthenticator(simulationSignature(tx.Sender))
+
+	result, err := c.nodes.SimulateTransaction(ctx, EncodeSignedTransaction(tx.ToBCSBytes(), auth), estimateMaxGasAmount)
+	if err != nil {
+		return TransactionDTO{}, errors.Wrap(err, "can't simulate transaction")
+	}
+
+	return result, nil
+}
+
+// simulationSignature returns a zeroed signature with the layout of the signer's signatures.
+// The node rejects valid signatures in simulations.
+func simulationSignature(signer Signer) []byte {
+	if s, ok := signer.(SimulationSigner); ok {
+		return s.SimulationSignature()
+	}
+
+	return make([]byte, ed25519.SignatureSize)
+}
+
 // IsTxExecuted checks if a transaction has been successfully executed on the blockchain.
 // It polls the node at regular intervals (100ms) until the transaction is executed or a timeout occurs (15 seconds).
 // The context can be used to cancel the operation or extend the timeout.
-// Returns true if the transaction has been executed successfully, false if it times out, or an error if the check fails.
+// Returns true if the transaction has been executed successfully, or false and an error if it times out,
+// if the check fails or if the execution failed. Execution failures are returned as soon as the transaction
+// is committed, as the typed errors of ParseVMStatus; Move aborts are explained with the module error map.
 func (c CedraClient) IsTxExecuted(ctx context.Context, txHash string) (bool, error) {
 	const period = 100 * time.Millisecond
 	const timeoutDuration = 15 * time.Second
@@ -170,21 +263,46 @@ func (c CedraClient) IsTxExecuted(ctx context.Context, txHash string) (bool, err
 	for {
 		select {
 		case <-ctx.Done():
-			return false, errors.New("transaction timeout")
+			return false, errors.Wrap(ctx.Err(), "transaction timeout")
 		case <-ticker.C:
-			tx, err := c.node.WaitTxByHash(txHash)
+			tx, err := c.nodes.WaitTxByHash(ctx, txHash)
+			var apiErr *APIError
+			if errors.As(err, &apiErr) && apiErr.ErrorCode == APIErrorCodeTransactionNotFound {
+				// The node may not have received the transaction yet.
+				continue
+			}
+			if err != nil && ctx.Err() != nil {
+				return false, errors.Wrap(ctx.Err(), "transaction timeout")
+			}
 			if err != nil {
 				return false, errors.Wrap(err, "can't wait for transaction")
 			}
-			if tx.VMStatus == executedTx && tx.TxType != pendingTx {
-				return true, nil
+			if tx.TxType == pendingTx {
+				continue
+			}
+			if err := ParseVMStatus(tx.VMStatus); err != nil {
+				return false, c.explainVMStatusError(ctx, err)
 			}
+
+			return true, nil
 		}
 	}
 }
 
+// GetTransactionByHash retrieves a pending or committed transaction by its hash,
+// including its gas usage, events and state changes once committed.
+func (c CedraClient) GetTransactionByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
+	return c.nodes.GetTransactionByHash(ctx, txHash)
+}
+
+// WaitTxByHash waits for a transaction to be processed and returns it,
+// including its gas usage, events and state changes once committed.
+func (c CedraClient) WaitTxByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
+	return c.nodes.WaitTxByHash(ctx, txHash)
+}
+
 // GetSequenceNumber retrieves the current sequence number for the specified account address.
 // Returns the sequence number as a uint64, or an error if the request fails.
-func (c CedraClient) GetSequenceNumber(address string) (uint64, error) {
-	return c.node.GetSequenceNumber(address)
+func (c CedraClient) GetSequenceNumber(ctx context.Context, address string) (uint64, error) {
+	return c.nodes.GetSequenceNumber(ctx, address)
 }
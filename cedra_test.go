@@ -0,0 +1,121 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"math"
+	"testing"
+)
+
+// newTestMultiKeyAccount returns a 2-of-3 multi-key account whose first key is secp256k1, with local signers.
+func newTestMultiKeyAccount(t *testing.T) MultiKeyAccount {
+	t.Helper()
+	secpAccount, err := NewSecp256k1Account("0x04" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := make([]AnyPublicKey, 0, 3)
+	for _, signer := range []Signer{secpAccount, account, other} {
+		publicKey, err := NewAnyPublicKey(signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKeys = append(publicKeys, publicKey)
+	}
+	multiKey, err := NewMultiKeyAccount(publicKeys, 2, secpAccount, account)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return multiKey
+}
+
+func TestNewTransactionRejectsInvalidGasOptions(t *testing.T) {
+	client, err := NewCedraClient(TestnetChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		options []any
+	}{
+		{name: "multiplier below 1", options: []any{SimulatedGasMultiplier(0.5)}},
+		{name: "zero multiplier", options: []any{SimulatedGasMultiplier(0)}},
+		{name: "negative multiplier", options: []any{SimulatedGasMultiplier(-2)}},
+		{name: "NaN multiplier", options: []any{SimulatedGasMultiplier(math.NaN())}},
+		{name: "max gas amount and multiplier", options: []any{MaxGasAmount(1000), SimulatedGasMultiplier(1.5)}},
+		{name: "multiplier and max gas amount", options: []any{SimulatedGasMultiplier(1.5), MaxGasAmount(1000)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The options are checked before any request is sent to the node.
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := client.NewTransaction(ctx, sender, &TransactionPayload{}, tt.options...); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestSimulationSignature(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secpAccount, err := NewSecp256k1Account("0x04" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiKey := newTestMultiKeyAccount(t)
+	multiEd25519, err := NewMultiEd25519Account([]ed25519.PublicKey{account.PublicKey, other.PublicKey}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The zeroed signatures of the first two keys, tagged with their schemes, and the bitmap of keys 0 and 1.
+	multiKeySignature := append([]byte{0x02, 0x01, 0x40}, make([]byte, 64)...)
+	multiKeySignature = append(multiKeySignature, 0x00, 0x40)
+	multiKeySignature = append(multiKeySignature, make([]byte, 64)...)
+	multiKeySignature = append(multiKeySignature, 0x04, 0xc0, 0x00, 0x00, 0x00)
+
+	tests := []struct {
+		name   string
+		signer Signer
+		want   []byte
+	}{
+		{name: "ed25519", signer: account, want: make([]byte, 64)},
+		{name: "ed25519 pointer", signer: &account, want: make([]byte, 64)},
+		{name: "secp256k1", signer: secpAccount, want: make([]byte, 64)},
+		{name: "multi ed25519", signer: multiEd25519, want: append(make([]byte, 128), 0xc0, 0x00, 0x00, 0x00)},
+		{name: "multi-key", signer: multiKey, want: multiKeySignature},
+		{name: "multi-key pointer", signer: &multiKey, want: multiKeySignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := simulationSignature(tt.signer); !bytes.Equal(got, tt.want) {
+				t.Fatalf("simulationSignature() = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,6 +11,9 @@ const (
 	TestnetChainID ChainID = 2
 	// MainnetChainID is the chain identifier for the main network.
 	MainnetChainID ChainID = 1
+
+	// LocalnetNodeURL is the default node API URL of a local network.
+	LocalnetNodeURL = "http://127.0.0.1:8080/v1/"
 )
 
 // ChainID represents a blockchain network identifier.
@@ -18,6 +21,7 @@ type ChainID uint8
 
 // NewLocalnetChainID creates a new chain ID for a local network.
 // Panics if the provided ID is 0, as chain IDs must be greater than 0.
+// Use it with the WithLocalnet or WithNodeURL node option, e.g. NewCedraClient(NewLocalnetChainID(4), WithLocalnet()).
 func NewLocalnetChainID(id uint8) ChainID {
 	if id == 0 {
 		panic(errors.New("can't create new chain id: chain id should be greater than 0"))
@@ -0,0 +1,222 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// moveBinaryMagic is the magic number starting every Move module bytecode.
+	moveBinaryMagic = "\xA1\x1C\xEB\x0B"
+	// moveMetadataTable is the table kind of the module metadata in Move module bytecode.
+	moveMetadataTable = 0x10
+	// runtimeMetadataKey is contained in the module metadata keys holding the runtime metadata
+	// with the error map, e.g. "cedra::metadata_v1".
+	runtimeMetadataKey = "::metadata_v"
+)
+
+// ErrorDescription is the human-readable explanation of a Move abort code, as published in module metadata.
+type ErrorDescription struct {
+	// CodeName is the name of the error constant (e.g. "EINSUFFICIENT_BALANCE").
+	CodeName string
+	// CodeDescription is the description of the error from its doc comment.
+	CodeDescription string
+}
+
+// DecodeModuleErrorMap extracts the error map, abort code to error description, from the runtime metadata
+// of Move module bytecode. Returns an empty map if the module was published without an error map.
+// Returns an error if the bytecode is malformed.
+func DecodeModuleErrorMap(bytecode []byte) (map[uint64]ErrorDescription, error) {
+	metadata, err := decodeModuleMetadata(bytecode)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode module metadata")
+	}
+
+	errorMap := make(map[uint64]ErrorDescription)
+	for key, value := range metadata {
+		if !strings.Contains(key, runtimeMetadataKey) {
+			continue
+		}
+		// The error map is the first field of every runtime metadata version.
+		bcs := NewBCSDecoder(value)
+		length, err := bcs.DecodeLength()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode error map")
+		}
+		for range length {
+			code, err := bcs.DecodeUint64()
+			if err != nil {
+				return nil, errors.Wrap(err, "can't decode error code")
+			}
+			name, err := bcs.DecodeString()
+			if err != nil {
+				return nil, errors.Wrap(err, "can't decode error name")
+			}
+			description, err := bcs.DecodeString()
+			if err != nil {
+				return nil, errors.Wrap(err, "can't decode error description")
+			}
+			errorMap[code] = ErrorDescription{CodeName: name, CodeDescription: description}
+		}
+	}
+
+	return errorMap, nil
+}
+
+// decodeModuleMetadata returns the key-value metadata entries of Move module bytecode.
+func decodeModuleMetadata(bytecode []byte) (map[string][]byte, error) {
+	if !bytes.HasPrefix(bytecode, []byte(moveBinaryMagic)) || len(bytecode) < len(moveBinaryMagic)+4 {
+		return nil, errors.New("not a move module bytecode")
+	}
+	// Skip the magic number and the binary format version.
+	header := NewBCSDecoder(bytecode[len(moveBinaryMagic)+4:])
+	tableCount, err := header.DecodeEnum()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode table count")
+	}
+
+	type table struct{ offset, length uint64 }
+	var metadataTable *table
+	for range tableCount {
+		kind, err := header.DecodeUint8()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode table kind")
+		}
+		offset, err := header.DecodeEnum()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode table offset")
+		}
+		length, err := header.DecodeEnum()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode table length")
+		}
+		if kind == moveMetadataTable {
+			metadataTable = &table{offset: offset, length: length}
+		}
+	}
+
+	metadata := make(map[string][]byte)
+	if metadataTable == nil {
+		return metadata, nil
+	}
+	contents := header.data[header.pos:]
+	if metadataTable.offset > uint64(len(contents)) || metadataTable.length > uint64(len(contents))-metadataTable.offset {
+		return nil, errors.New("metadata table out of bounds")
+	}
+
+	bcs := NewBCSDecoder(contents[metadataTable.offset : metadataTable.offset+metadataTable.length])
+	for bcs.Remaining() > 0 {
+		key, err := bcs.DecodeString()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode metadata key")
+		}
+		value, err := bcs.DecodeBytes()
+		if err != nil {
+			return nil, errors.Wrap(err, "can't decode metadata value")
+		}
+		metadata[key] = value
+	}
+
+	return metadata, nil
+}
+
+// errorMapCache caches the error maps of modules, keyed by module location (e.g. "0x1::coin").
+type errorMapCache struct {
+	mu        sync.RWMutex
+	errorMaps map[string]map[uint64]ErrorDescription
+}
+
+// newErrorMapCache creates an empty error map cache.
+func newErrorMapCache() *errorMapCache {
+	return &errorMapCache{errorMaps: make(map[string]map[uint64]ErrorDescription)}
+}
+
+// get returns the cached error map of the module.
+func (c *errorMapCache) get(location string) (map[uint64]ErrorDescription, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	errorMap, ok := c.errorMaps[location]
+
+	return errorMap, ok
+}
+
+// set caches the error map of the module.
+func (c *errorMapCache) set(location string, errorMap map[uint64]ErrorDescription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errorMaps[location] = errorMap
+}
+
+// GetModuleErrorMap returns the error map of the module at the location (e.g. "0x1::coin"),
+// fetching the module bytecode from the network on first use and caching it afterwards.
+func (c CedraClient) GetModuleErrorMap(ctx context.Context, location string) (map[uint64]ErrorDescription, error) {
+	if c.errorMaps != nil {
+		if errorMap, ok := c.errorMaps.get(location); ok {
+			return errorMap, nil
+		}
+	}
+
+	address, moduleName, ok := strings.Cut(location, "::")
+	if !ok {
+		return nil, errors.Errorf("can't get error map: invalid module location %q", location)
+	}
+	module, err := c.nodes.GetAccountModule(ctx, address, moduleName)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get error map")
+	}
+	bytecode, err := decodeHexBytes(module.Bytecode)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get error map: invalid module bytecode")
+	}
+	errorMap, err := DecodeModuleErrorMap(bytecode)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get error map")
+	}
+
+	if c.errorMaps != nil {
+		c.errorMaps.set(location, errorMap)
+	}
+
+	return errorMap, nil
+}
+
+// ExplainMoveAbort fills the Reason and Description of the abort from the error map of the aborting module,
+// if the VM status didn't include them. Aborts raised by scripts can't be explained.
+// Returns an error if the error map can't be fetched.
+func (c CedraClient) ExplainMoveAbort(ctx context.Context, abort *MoveAbortError) error {
+	if abort.Reason != "" || !strings.Contains(abort.Location, "::") {
+		return nil
+	}
+	errorMap, err := c.GetModuleErrorMap(ctx, abort.Location)
+	if err != nil {
+		return err
+	}
+
+	description, ok := errorMap[abort.ReasonCode()]
+	if !ok {
+		description, ok = errorMap[abort.Code]
+	}
+	if ok {
+		abort.Reason = description.CodeName
+		abort.Description = description.CodeDescription
+	}
+
+	return nil
+}
+
+// explainVMStatusError attaches the reason of Move aborts to a VM status error when it can be resolved.
+func (c CedraClient) explainVMStatusError(ctx context.Context, err error) error {
+	var abort *MoveAbortError
+	if errors.As(err, &abort) {
+		// The abort is still reported without explanation if the error map is unavailable.
+		if explainErr := c.ExplainMoveAbort(ctx, abort); explainErr != nil {
+			abort.ExplainErr = explainErr
+		}
+	}
+
+	return err
+}
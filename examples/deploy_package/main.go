@@ -1,13 +1,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 
 	"github.com/cedra-labs/cedra-go-tx-pablisher"
-	"github.com/spf13/cast"
 )
 
 const (
@@ -16,7 +16,11 @@ const (
 )
 
 func main() {
-	cedraClient := cedra.NewCedraClient(cedra.TestnetChainID)
+	ctx := context.TODO()
+	cedraClient, err := cedra.NewCedraClient(cedra.TestnetChainID)
+	if err != nil {
+		panic(err)
+	}
 	sender, err := cedra.NewAccount(privateKey)
 	if err != nil {
 		panic(err)
@@ -44,7 +48,10 @@ func main() {
 	}
 
 	serializedMeta := cedra.EncodeToBCSBytes(metaBytes)
-	serializedModules := ModuleList(modules).EncodeModule()
+	serializedModules, err := cedra.Marshal(modules)
+	if err != nil {
+		log.Fatalf("encode modules: %v", err)
+	}
 	serializedSeed := cedra.EncodeToBCSBytes([]byte(""))
 
 	payload := cedra.TransactionPayload{
@@ -58,28 +65,19 @@ func main() {
 		},
 	}
 
-	rawTx, err := cedraClient.NewTransaction(sender, &payload)
+	rawTx, err := cedraClient.NewTransaction(ctx, sender, &payload)
 	if err != nil {
 		panic(err)
 	}
-	encodedTx, auth := rawTx.Sign()
-
-	hash, err := cedraClient.SubmitTransaction(encodedTx, auth)
+	encodedTx, auth, err := rawTx.Sign(ctx)
 	if err != nil {
 		panic(err)
 	}
 
-	fmt.Println(hash)
-}
-
-type ModuleList [][]byte
-
-func (m ModuleList) EncodeModule() []byte {
-	ser := cedra.NewBCSEncoder()
-	ser.EncodeEnum(cast.ToUint64(len(m))) // Length of the outer vector
-	for _, module := range m {
-		ser.EncodeBytes(module)
+	hash, err := cedraClient.SubmitTransaction(ctx, encodedTx, auth)
+	if err != nil {
+		panic(err)
 	}
 
-	return ser.GetBytes()
+	fmt.Println(hash)
 }
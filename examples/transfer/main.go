@@ -17,7 +17,10 @@ const (
 
 func main() {
 	ctx := context.TODO()
-	cedraClient := cedra.NewCedraClient(cedra.TestnetChainID)
+	cedraClient, err := cedra.NewCedraClient(cedra.TestnetChainID)
+	if err != nil {
+		panic(err)
+	}
 
 	sender, err := cedra.NewAccount(privateKey)
 	if err != nil {
@@ -43,10 +46,16 @@ func main() {
 		},
 	}
 
-	rawTx, err := cedraClient.NewTransaction(sender, &payload)
-	encodedTx, authenticator := rawTx.Sign()
+	rawTx, err := cedraClient.NewTransaction(ctx, sender, &payload)
+	if err != nil {
+		panic(err)
+	}
+	encodedTx, authenticator, err := rawTx.Sign(ctx)
+	if err != nil {
+		panic(err)
+	}
 
-	hash, err := cedraClient.SubmitTransaction(encodedTx, authenticator)
+	hash, err := cedraClient.SubmitTransaction(ctx, encodedTx, authenticator)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,267 @@
+package cedra
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// feePayerTransactionVariant is the RawTransactionWithData variant identifier for fee payer transactions.
+	feePayerTransactionVariant = 1
+)
+
+// FeePayerTransaction represents a sponsored transaction: the sender authorizes the transaction
+// while the fee payer account pays its gas fees. Both accounts sign the same signing message.
+type FeePayerTransaction struct {
+	// Transaction is the raw transaction, sent by its Sender.
+	Transaction *Transaction
+	// FeePayerAddress is the address of the account paying the gas fees.
+	FeePayerAddress [32]byte
+}
+
+// NewFeePayerTransaction wraps the transaction into a fee payer transaction whose gas fees are paid by the fee payer account.
+func NewFeePayerTransaction(tx *Transaction, feePayerAddress [32]byte) *FeePayerTransaction {
+	return &FeePayerTransaction{
+		Transaction:     tx,
+		FeePayerAddress: feePayerAddress,
+	}
+}
+
+// ToBCSBytes encodes the fee payer transaction data into Binary Canonical Serialization (BCS) format:
+// the raw transaction followed by the secondary signer addresses and the fee payer address.
+// Returns the serialized byte representation of the transaction data.
+func (tx *FeePayerTransaction) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(feePayerTransactionVariant)
+	bcs.WriteRawBytes(tx.Transaction.ToBCSBytes())
+	encodeAddresses(bcs, nil)
+	bcs.WriteRawBytes(tx.FeePayerAddress[:])
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes fee payer transaction data encoded with ToBCSBytes.
+// As with DecodeTransaction, the Sender of the transaction is an Account without keys.
+func (tx *FeePayerTransaction) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(feePayerTransactionVariant, "fee payer transaction"); err != nil {
+		return err
+	}
+	decoded, err := decodeFeePayerTransaction(bcs)
+	if err != nil {
+		return err
+	}
+	*tx = *decoded
+
+	return nil
+}
+
+// SigningMessage returns the message signed by both the sender and the fee payer:
+// the SHA3-256 hash of the transaction with data prefix followed by the encoded transaction data.
+func (tx *FeePayerTransaction) SigningMessage() []byte {
+	return newSigningMessage(transactionWithDataPrefix, tx.ToBCSBytes())
+}
+
+// SignAs signs the transaction with the signer of one of its accounts, the sender or the fee payer.
+// Use it when the accounts sign on separate machines, then combine the results with NewAuthenticator.
+// The context cancels the signing if the signer is a ContextSigner.
+// Returns an error if the signer isn't one of the transaction accounts.
+func (tx *FeePayerTransaction) SignAs(ctx context.Context, signer Signer) (AccountAuthenticator, error) {
+	address := signer.GetAccountAddress()
+	if address != tx.Transaction.Sender.GetAccountAddress() && address != tx.FeePayerAddress {
+		return AccountAuthenticator{}, errors.New("can't sign fee payer transaction: signer is neither the sender nor the fee payer")
+	}
+	signature, err := signMessage(ctx, signer, tx.SigningMessage())
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't sign fee payer transaction")
+	}
+	accountAuth, err := signer.NewAuthenticator(signature).ToAccountAuthenticator()
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't sign fee payer transaction")
+	}
+
+	return accountAuth, nil
+}
+
+// NewAuthenticator combines the sender and fee payer account authenticators produced by SignAs
+// into the transaction authenticator.
+func (tx *FeePayerTransaction) NewAuthenticator(sender AccountAuthenticator, feePayer AccountAuthenticator) CedraAuthenticator {
+	return CedraAuthenticator{
+		Variant: feePayerTxVariant,
+		Auth: FeePayerAuth{
+			Sender:          sender,
+			FeePayerAddress: tx.FeePayerAddress,
+			FeePayer:        feePayer,
+		},
+	}
+}
+
+// Sign signs the transaction with both the sender's signer and the fee payer's signer.
+// Returns the encoded raw transaction bytes and the authenticator for submission.
+// The context cancels the signing of ContextSigner signers.
+func (tx *FeePayerTransaction) Sign(ctx context.Context, feePayer Signer) ([]byte, CedraAuthenticator, error) {
+	if feePayer.GetAccountAddress() != tx.FeePayerAddress {
+		return nil, CedraAuthenticator{}, errors.New("can't sign fee payer transaction: signer isn't the fee payer")
+	}
+	senderAuth, err := tx.SignAs(ctx, tx.Transaction.Sender)
+	if err != nil {
+		return nil, CedraAuthenticator{}, err
+	}
+	feePayerAuth, err := tx.SignAs(ctx, feePayer)
+	if err != nil {
+		return nil, CedraAuthenticator{}, err
+	}
+
+	return tx.Transaction.ToBCSBytes(), tx.NewAuthenticator(senderAuth, feePayerAuth), nil
+}
+
+// FeePayerAuth contains the authentication data of a fee payer transaction.
+type FeePayerAuth struct {
+	// Sender is the account authenticator of the transaction sender.
+	Sender AccountAuthenticator
+	// SecondarySignerAddresses are the addresses of the secondary signers of the transaction, if any.
+	SecondarySignerAddresses [][32]byte
+	// SecondarySigners are the account authenticators of the secondary signers, in the same order as their addresses.
+	SecondarySigners []AccountAuthenticator
+	// FeePayerAddress is the address of the account paying the gas fees.
+	FeePayerAddress [32]byte
+	// FeePayer is the account authenticator of the fee payer.
+	FeePayer AccountAuthenticator
+}
+
+// EncodeBSC encodes the fee payer authentication into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the authentication data.
+func (a FeePayerAuth) EncodeBSC() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.WriteRawBytes(a.Sender.EncodeBSC())
+	encodeAddresses(bcs, a.SecondarySignerAddresses)
+	bcs.EncodeEnum(uint64(len(a.SecondarySigners)))
+	for _, signer := range a.SecondarySigners {
+		bcs.WriteRawBytes(signer.EncodeBSC())
+	}
+	bcs.WriteRawBytes(a.FeePayerAddress[:])
+	bcs.WriteRawBytes(a.FeePayer.EncodeBSC())
+
+	return bcs.GetBytes()
+}
+
+// NewFeePayerTransaction creates a fee payer transaction with the provided sender signer and payload,
+// whose gas fees are paid by the fee payer account.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewFeePayerTransaction(ctx context.Context, sender Signer, feePayerAddress [32]byte, payload Payload, options ...any) (*FeePayerTransaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, payload, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create fee payer transaction")
+	}
+
+	return NewFeePayerTransaction(tx, feePayerAddress), nil
+}
+
+// SubmitFeePayerTransaction submits a fee payer transaction signed by the sender and the fee payer,
+// e.g. with FeePayerTransaction.SignAs on separate machines.
+// Returns the transaction hash if successful, or an error if submission fails.
+func (c CedraClient) SubmitFeePayerTransaction(ctx context.Context, tx *FeePayerTransaction, sender AccountAuthenticator, feePayer AccountAuthenticator) (string, error) {
+	return c.SubmitTransaction(ctx, tx.Transaction.ToBCSBytes(), tx.NewAuthenticator(sender, feePayer))
+}
+
+// encodeAddresses encodes a vector of account addresses.
+func encodeAddresses(bcs *BCSEncoder, addresses [][32]byte) {
+	bcs.EncodeEnum(uint64(len(addresses)))
+	for _, address := range addresses {
+		bcs.WriteRawBytes(address[:])
+	}
+}
+
+// decodeAddresses reads a vector of account addresses from the decoder.
+func decodeAddresses(bcs *BCSDecoder) ([][32]byte, error) {
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([][32]byte, 0, length)
+	for range length {
+		address, err := bcs.DecodeAddress()
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+
+	return addresses, nil
+}
+
+// decodeAccountAuthenticators reads a vector of account authenticators from the decoder.
+func decodeAccountAuthenticators(bcs *BCSDecoder) ([]AccountAuthenticator, error) {
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return nil, err
+	}
+	authenticators := make([]AccountAuthenticator, 0, length)
+	for range length {
+		authenticator, err := decodeAccountAuthenticator(bcs)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, authenticator)
+	}
+
+	return authenticators, nil
+}
+
+// decodeFeePayerAuth reads the authentication data of a fee payer transaction from the decoder.
+func decodeFeePayerAuth(bcs *BCSDecoder) (FeePayerAuth, error) {
+	sender, err := decodeAccountAuthenticator(bcs)
+	if err != nil {
+		return FeePayerAuth{}, errors.Wrap(err, "can't decode sender authenticator")
+	}
+	secondaryAddresses, err := decodeAddresses(bcs)
+	if err != nil {
+		return FeePayerAuth{}, errors.Wrap(err, "can't decode secondary signer addresses")
+	}
+	secondarySigners, err := decodeAccountAuthenticators(bcs)
+	if err != nil {
+		return FeePayerAuth{}, errors.Wrap(err, "can't decode secondary signer authenticators")
+	}
+	if len(secondarySigners) != len(secondaryAddresses) {
+		return FeePayerAuth{}, errors.New("secondary signer addresses don't match their authenticators")
+	}
+	feePayerAddress, err := bcs.DecodeAddress()
+	if err != nil {
+		return FeePayerAuth{}, errors.Wrap(err, "can't decode fee payer address")
+	}
+	feePayer, err := decodeAccountAuthenticator(bcs)
+	if err != nil {
+		return FeePayerAuth{}, errors.Wrap(err, "can't decode fee payer authenticator")
+	}
+
+	return FeePayerAuth{
+		Sender:                   sender,
+		SecondarySignerAddresses: secondaryAddresses,
+		SecondarySigners:         secondarySigners,
+		FeePayerAddress:          feePayerAddress,
+		FeePayer:                 feePayer,
+	}, nil
+}
+
+// decodeFeePayerTransaction decodes the fields of fee payer transaction data that follow its variant.
+func decodeFeePayerTransaction(bcs *BCSDecoder) (*FeePayerTransaction, error) {
+	tx, err := decodeTransaction(bcs)
+	if err != nil {
+		return nil, err
+	}
+	secondaryAddresses, err := decodeAddresses(bcs)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode secondary signer addresses")
+	}
+	if len(secondaryAddresses) != 0 {
+		return nil, errors.New("fee payer transactions with secondary signers are not supported")
+	}
+	feePayerAddress, err := bcs.DecodeAddress()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode fee payer address")
+	}
+
+	return NewFeePayerTransaction(&tx, feePayerAddress), nil
+}
@@ -0,0 +1,162 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+// testTransactionWithDataPrefix is the SHA3-256 hash of "CEDRA::RawTransactionWithData".
+const testTransactionWithDataPrefix = "dc1cd51bc0103d1c06a90c8ad02db5b5b1c4e4dab7a1f3a96b3a93d7b0cd3159"
+
+func TestFeePayerTransactionBCS(t *testing.T) {
+	tx := newTestTransaction(t)
+	feePayerAddress := [32]byte{31: 0xfe}
+	feePayerTx := NewFeePayerTransaction(&tx, feePayerAddress)
+
+	// Fee payer variant, raw transaction, empty secondary signers, then the fee payer address.
+	want := append([]byte{0x01}, tx.ToBCSBytes()...)
+	want = append(want, 0x00)
+	want = append(want, feePayerAddress[:]...)
+	if got := feePayerTx.ToBCSBytes(); !bytes.Equal(got, want) {
+		t.Fatalf("ToBCSBytes() = %x, want %x", got, want)
+	}
+
+	wantMessage := append(mustDecodeHex(t, testTransactionWithDataPrefix), want...)
+	if got := feePayerTx.SigningMessage(); !bytes.Equal(got, wantMessage) {
+		t.Fatalf("SigningMessage() = %x, want %x", got, wantMessage)
+	}
+
+	bcs := NewBCSDecoder(want[1:])
+	decoded, err := decodeFeePayerTransaction(bcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bcs.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.FeePayerAddress != feePayerAddress || !bytes.Equal(decoded.ToBCSBytes(), want) {
+		t.Fatalf("decodeFeePayerTransaction() encodes to %x, want %x", decoded.ToBCSBytes(), want)
+	}
+
+	var unmarshaled FeePayerTransaction
+	if err := Unmarshal(want, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unmarshaled.ToBCSBytes(), want) {
+		t.Fatalf("Unmarshal() encodes to %x, want %x", unmarshaled.ToBCSBytes(), want)
+	}
+}
+
+func TestDecodeFeePayerTransactionRejectsInvalidInput(t *testing.T) {
+	tx := newTestTransaction(t)
+	feePayerAddress := [32]byte{31: 0xfe}
+
+	withSecondarySigner := append(tx.ToBCSBytes(), 0x01)
+	withSecondarySigner = append(withSecondarySigner, feePayerAddress[:]...)
+	withSecondarySigner = append(withSecondarySigner, feePayerAddress[:]...)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "secondary signers", data: withSecondarySigner},
+		{name: "missing fee payer address", data: append(tx.ToBCSBytes(), 0x00)},
+		{name: "truncated fee payer address", data: append(append(tx.ToBCSBytes(), 0x00), feePayerAddress[:31]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeFeePayerTransaction(NewBCSDecoder(tt.data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	var decoded FeePayerTransaction
+	if err := Unmarshal(append([]byte{0x00}, tx.ToBCSBytes()...), &decoded); err == nil {
+		t.Fatal("expected an error for the multi-agent variant")
+	}
+}
+
+func TestFeePayerAuthenticatorBCS(t *testing.T) {
+	sender, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feePayer, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	feePayerTx := NewFeePayerTransaction(&tx, feePayer.GetAccountAddress())
+
+	senderSignature := bytes.Repeat([]byte{0xaa}, ed25519.SignatureSize)
+	feePayerSignature := bytes.Repeat([]byte{0xbb}, ed25519.SignatureSize)
+	senderAuth, err := sender.NewAuthenticator(senderSignature).ToAccountAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	feePayerAuth, err := feePayer.NewAuthenticator(feePayerSignature).ToAccountAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fee payer variant, the sender's ED25519 account authenticator, empty secondary signer
+	// addresses and authenticators, then the fee payer address and ED25519 account authenticator.
+	feePayerAddress := feePayer.GetAccountAddress()
+	want := []byte{0x03, 0x00, 0x20}
+	want = append(want, sender.PublicKey...)
+	want = append(want, 0x40)
+	want = append(want, senderSignature...)
+	want = append(want, 0x00, 0x00)
+	want = append(want, feePayerAddress[:]...)
+	want = append(want, 0x00, 0x20)
+	want = append(want, feePayer.PublicKey...)
+	want = append(want, 0x40)
+	want = append(want, feePayerSignature...)
+	authenticator := feePayerTx.NewAuthenticator(senderAuth, feePayerAuth)
+	if got := authenticator.EncodeBSC(); !bytes.Equal(got, want) {
+		t.Fatalf("EncodeBSC() = %x, want %x", got, want)
+	}
+
+	decoded, err := DecodeCedraAuthenticator(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.EncodeBSC(), want) {
+		t.Fatalf("decoded authenticator encodes to %x, want %x", decoded.EncodeBSC(), want)
+	}
+}
+
+func TestFeePayerTransactionSign(t *testing.T) {
+	feePayer, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	feePayerTx := NewFeePayerTransaction(&tx, feePayer.GetAccountAddress())
+
+	encoded, authenticator, err := feePayerTx.Sign(context.Background(), feePayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, tx.ToBCSBytes()) {
+		t.Fatalf("Sign() encoded %x, want the raw transaction", encoded)
+	}
+	auth, ok := authenticator.Auth.(FeePayerAuth)
+	if !ok || authenticator.Variant != feePayerTxVariant {
+		t.Fatalf("Sign() authenticator = %+v, want a fee payer authenticator", authenticator)
+	}
+	for name, accountAuth := range map[string]AccountAuthenticator{"sender": auth.Sender, "fee payer": auth.FeePayer} {
+		senderAuth := accountAuth.Auth.(SenderAuth)
+		if !ed25519.Verify(senderAuth.PKey, feePayerTx.SigningMessage(), senderAuth.Signature) {
+			t.Fatalf("invalid %s signature %s", name, hex.EncodeToString(senderAuth.Signature))
+		}
+	}
+
+	if _, _, err := feePayerTx.Sign(context.Background(), tx.Sender); err == nil {
+		t.Fatal("expected an error for a signer that isn't the fee payer")
+	}
+}
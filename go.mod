@@ -3,6 +3,7 @@ module github.com/cedra-labs/cedra-go-tx-pablisher
 go 1.25.4
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.4.0
 	github.com/pkg/errors v0.9.1
 	github.com/spf13/cast v1.10.0
 )
@@ -1,5 +1,7 @@
 package cedra
 
+import "encoding/json"
+
 // AccountDTO represents the account information returned from the Cedra node API.
 type AccountDTO struct {
 	// SequenceNumber is the current sequence number of the account.
@@ -18,12 +20,139 @@ type EstimateGasPriceDTO struct {
 	PrioritizedGasEstimate uint64 `json:"prioritized_gas_estimate"`
 }
 
-// TransactionDTO represents the transaction response from the Cedra node API.
+// TransactionDTO represents a transaction returned from the Cedra node API: a pending transaction
+// after submission, or a committed (or simulated) user transaction with its execution result.
+// Execution fields are empty for pending transactions.
 type TransactionDTO struct {
-	// Hash is the transaction hash returned after submission.
+	// TxType is the type of the transaction (e.g., "pending_transaction" or "user_transaction").
+	TxType string `json:"type"`
+	// Hash is the transaction hash.
 	Hash string `json:"hash"`
+	// Version is the ledger version at which the transaction was committed.
+	Version uint64 `json:"version,string"`
+	// Success reports whether the transaction was executed successfully.
+	Success bool `json:"success"`
 	// VMStatus is the virtual machine execution status of the transaction (e.g., "Executed successfully").
 	VMStatus string `json:"vm_status"`
-	// TxType is the type of the transaction (e.g., "pending_transaction" for pending transactions).
-	TxType string `json:"type"`
+	// GasUsed is the amount of gas units consumed by the transaction.
+	GasUsed uint64 `json:"gas_used,string"`
+	// GasUnitPrice is the price per gas unit.
+	GasUnitPrice uint64 `json:"gas_unit_price,string"`
+	// MaxGasAmount is the maximum amount of gas units the transaction can consume.
+	MaxGasAmount uint64 `json:"max_gas_amount,string"`
+	// Sender is the address of the transaction sender.
+	Sender string `json:"sender"`
+	// SequenceNumber is the sequence number of the transaction in the sender account.
+	SequenceNumber uint64 `json:"sequence_number,string"`
+	// ExpirationTimestampSeconds is the Unix timestamp when the transaction expires.
+	ExpirationTimestampSeconds uint64 `json:"expiration_timestamp_secs,string"`
+	// Payload is the transaction payload.
+	Payload TransactionPayloadDTO `json:"payload"`
+	// Signature is the JSON-encoded transaction authenticator.
+	Signature json.RawMessage `json:"signature,omitempty"`
+	// Events are the events emitted by the transaction.
+	Events []EventDTO `json:"events"`
+	// Changes are the state changes made by the transaction.
+	Changes []WriteSetChangeDTO `json:"changes"`
+	// Timestamp is the Unix timestamp in microseconds of the block containing the transaction.
+	Timestamp uint64 `json:"timestamp,string"`
+	// StateChangeHash is the hash of the state changes made by the transaction.
+	StateChangeHash string `json:"state_change_hash"`
+	// EventRootHash is the root hash of the events emitted by the transaction.
+	EventRootHash string `json:"event_root_hash"`
+	// StateCheckpointHash is the state checkpoint hash, if the transaction ends a block.
+	StateCheckpointHash string `json:"state_checkpoint_hash"`
+	// AccumulatorRootHash is the root hash of the transaction accumulator after the transaction.
+	AccumulatorRootHash string `json:"accumulator_root_hash"`
+}
+
+// TransactionPayloadDTO represents a transaction payload returned from the Cedra node API.
+type TransactionPayloadDTO struct {
+	// Type is the payload kind: "entry_function_payload", "script_payload" or "multisig_payload".
+	Type string `json:"type"`
+	// Function is the called entry function (e.g. "0x1::cedra_account::transfer"), if any.
+	Function string `json:"function,omitempty"`
+	// Code is the JSON-encoded bytecode and ABI of script payloads.
+	Code json.RawMessage `json:"code,omitempty"`
+	// TypeArguments are the type arguments of the function or script.
+	TypeArguments []string `json:"type_arguments,omitempty"`
+	// Arguments are the JSON-encoded arguments of the function or script.
+	Arguments []json.RawMessage `json:"arguments,omitempty"`
+	// MultisigAddress is the multisig account address for multisig payloads.
+	MultisigAddress string `json:"multisig_address,omitempty"`
+	// TransactionPayload is the entry function executed by multisig payloads, if provided.
+	TransactionPayload *TransactionPayloadDTO `json:"transaction_payload,omitempty"`
+}
+
+// EventDTO represents an event emitted by a transaction, as returned from the Cedra node API.
+type EventDTO struct {
+	// GUID identifies the event handle of the event. It is zeroed for module events.
+	GUID EventGUIDDTO `json:"guid"`
+	// SequenceNumber is the sequence number of the event in its event handle.
+	SequenceNumber uint64 `json:"sequence_number,string"`
+	// Type is the Move type of the event (e.g., "0x1::fungible_asset::Withdraw").
+	Type string `json:"type"`
+	// Data is the JSON-encoded event data.
+	Data json.RawMessage `json:"data"`
+}
+
+// EventGUIDDTO represents the identifier of an event handle.
+type EventGUIDDTO struct {
+	// CreationNumber is the creation number of the event handle in the account.
+	CreationNumber uint64 `json:"creation_number,string"`
+	// AccountAddress is the address of the account owning the event handle.
+	AccountAddress string `json:"account_address"`
+}
+
+// WriteSetChangeDTO represents a state change made by a transaction, as returned from the Cedra node API.
+type WriteSetChangeDTO struct {
+	// Type is the kind of change: "write_resource", "delete_resource", "write_module", "delete_module",
+	// "write_table_item" or "delete_table_item".
+	Type string `json:"type"`
+	// Address is the address of the account whose resource or module changed.
+	Address string `json:"address,omitempty"`
+	// StateKeyHash is the hash of the changed state key.
+	StateKeyHash string `json:"state_key_hash"`
+	// Resource is the Move type of the deleted resource for "delete_resource" changes.
+	Resource string `json:"resource,omitempty"`
+	// Module is the name of the deleted module for "delete_module" changes.
+	Module string `json:"module,omitempty"`
+	// Handle is the handle of the changed table for table item changes.
+	Handle string `json:"handle,omitempty"`
+	// Key is the hex-encoded key of the changed table item.
+	Key string `json:"key,omitempty"`
+	// Value is the hex-encoded new value of written table items.
+	Value string `json:"value,omitempty"`
+	// Data is the JSON-encoded written resource, module or decoded table item, if any.
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// MoveModuleDTO represents a published Move module returned from the Cedra node API.
+type MoveModuleDTO struct {
+	// Bytecode is the hex-encoded module bytecode.
+	Bytecode string `json:"bytecode"`
+	// ABI is the JSON-encoded module ABI.
+	ABI json.RawMessage `json:"abi"`
+}
+
+// LedgerInfoDTO represents the ledger information returned by the root endpoint of the Cedra node API.
+type LedgerInfoDTO struct {
+	// ChainID identifies the blockchain network of the node.
+	ChainID uint8 `json:"chain_id"`
+	// Epoch is the current epoch.
+	Epoch uint64 `json:"epoch,string"`
+	// LedgerVersion is the latest ledger version of the node.
+	LedgerVersion uint64 `json:"ledger_version,string"`
+	// OldestLedgerVersion is the oldest ledger version the node still stores.
+	OldestLedgerVersion uint64 `json:"oldest_ledger_version,string"`
+	// LedgerTimestamp is the timestamp of the latest ledger version in microseconds.
+	LedgerTimestamp uint64 `json:"ledger_timestamp,string"`
+	// NodeRole is the role of the node (e.g., "full_node" or "validator").
+	NodeRole string `json:"node_role"`
+	// OldestBlockHeight is the oldest block height the node still stores.
+	OldestBlockHeight uint64 `json:"oldest_block_height,string"`
+	// BlockHeight is the latest block height of the node.
+	BlockHeight uint64 `json:"block_height,string"`
+	// GitHash is the git hash of the node build.
+	GitHash string `json:"git_hash"`
 }
@@ -0,0 +1,226 @@
+package cedra
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// multiAgentTransactionVariant is the RawTransactionWithData variant identifier for multi-agent transactions.
+	multiAgentTransactionVariant = 0
+)
+
+// MultiAgentTransaction represents a transaction signed by its sender and by secondary signers,
+// for entry functions and scripts taking several &signer arguments (e.g. atomic swaps).
+// The sender and every secondary signer sign the same signing message.
+type MultiAgentTransaction struct {
+	// Transaction is the raw transaction, sent by its Sender.
+	Transaction *Transaction
+	// SecondarySignerAddresses are the addresses of the secondary signers, in the order
+	// of the &signer arguments following the sender.
+	SecondarySignerAddresses [][32]byte
+}
+
+// NewMultiAgentTransaction wraps the transaction into a multi-agent transaction with the secondary signers.
+func NewMultiAgentTransaction(tx *Transaction, secondarySignerAddresses ...[32]byte) *MultiAgentTransaction {
+	return &MultiAgentTransaction{
+		Transaction:              tx,
+		SecondarySignerAddresses: secondarySignerAddresses,
+	}
+}
+
+// ToBCSBytes encodes the multi-agent transaction data into Binary Canonical Serialization (BCS) format:
+// the raw transaction followed by the secondary signer addresses.
+// Returns the serialized byte representation of the transaction data.
+func (tx *MultiAgentTransaction) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(multiAgentTransactionVariant)
+	bcs.WriteRawBytes(tx.Transaction.ToBCSBytes())
+	encodeAddresses(bcs, tx.SecondarySignerAddresses)
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes multi-agent transaction data encoded with ToBCSBytes.
+// As with DecodeTransaction, the Sender of the transaction is an Account without keys.
+func (tx *MultiAgentTransaction) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(multiAgentTransactionVariant, "multi-agent transaction"); err != nil {
+		return err
+	}
+	decoded, err := decodeMultiAgentTransaction(bcs)
+	if err != nil {
+		return err
+	}
+	*tx = *decoded
+
+	return nil
+}
+
+// SigningMessage returns the message signed by the sender and every secondary signer:
+// the SHA3-256 hash of the transaction with data prefix followed by the encoded transaction data.
+func (tx *MultiAgentTransaction) SigningMessage() []byte {
+	return newSigningMessage(transactionWithDataPrefix, tx.ToBCSBytes())
+}
+
+// SignAs signs the transaction with the signer of one of its accounts, the sender or a secondary signer.
+// Use it when the accounts sign on separate machines, then combine the results with NewAuthenticator.
+// The context cancels the signing if the signer is a ContextSigner.
+// Returns an error if the signer isn't one of the transaction accounts.
+func (tx *MultiAgentTransaction) SignAs(ctx context.Context, signer Signer) (AccountAuthenticator, error) {
+	address := signer.GetAccountAddress()
+	if address != tx.Transaction.Sender.GetAccountAddress() && !slices.Contains(tx.SecondarySignerAddresses, address) {
+		return AccountAuthenticator{}, errors.New("can't sign multi-agent transaction: signer is neither the sender nor a secondary signer")
+	}
+	signature, err := signMessage(ctx, signer, tx.SigningMessage())
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't sign multi-agent transaction")
+	}
+	accountAuth, err := signer.NewAuthenticator(signature).ToAccountAuthenticator()
+	if err != nil {
+		return AccountAuthenticator{}, errors.Wrap(err, "can't sign multi-agent transaction")
+	}
+
+	return accountAuth, nil
+}
+
+// NewAuthenticator combines the sender and secondary signer account authenticators produced by SignAs
+// into the transaction authenticator. The secondary signers must be in the order of SecondarySignerAddresses.
+// Returns an error if the number of secondary signers doesn't match the addresses.
+func (tx *MultiAgentTransaction) NewAuthenticator(sender AccountAuthenticator, secondarySigners []AccountAuthenticator) (CedraAuthenticator, error) {
+	if len(secondarySigners) != len(tx.SecondarySignerAddresses) {
+		return CedraAuthenticator{}, errors.Errorf("can't create multi-agent authenticator: %d secondary signers, %d expected",
+			len(secondarySigners), len(tx.SecondarySignerAddresses))
+	}
+
+	return CedraAuthenticator{
+		Variant: multiAgentTxVariant,
+		Auth: MultiAgentAuth{
+			Sender:                   sender,
+			SecondarySignerAddresses: tx.SecondarySignerAddresses,
+			SecondarySigners:         secondarySigners,
+		},
+	}, nil
+}
+
+// Sign signs the transaction with the sender's signer and the signers of every secondary signer address.
+// The secondary signers can be provided in any order.
+// Returns the encoded raw transaction bytes and the authenticator for submission.
+// The context cancels the signing of ContextSigner signers.
+func (tx *MultiAgentTransaction) Sign(ctx context.Context, secondarySigners ...Signer) ([]byte, CedraAuthenticator, error) {
+	senderAuth, err := tx.SignAs(ctx, tx.Transaction.Sender)
+	if err != nil {
+		return nil, CedraAuthenticator{}, err
+	}
+
+	secondaryAuths := make([]AccountAuthenticator, 0, len(tx.SecondarySignerAddresses))
+	for _, address := range tx.SecondarySignerAddresses {
+		index := slices.IndexFunc(secondarySigners, func(signer Signer) bool {
+			return signer.GetAccountAddress() == address
+		})
+		if index < 0 {
+			return nil, CedraAuthenticator{}, errors.Errorf("can't sign multi-agent transaction: missing signer of %x", address)
+		}
+		secondaryAuth, err := tx.SignAs(ctx, secondarySigners[index])
+		if err != nil {
+			return nil, CedraAuthenticator{}, err
+		}
+		secondaryAuths = append(secondaryAuths, secondaryAuth)
+	}
+
+	authenticator, err := tx.NewAuthenticator(senderAuth, secondaryAuths)
+	if err != nil {
+		return nil, CedraAuthenticator{}, err
+	}
+
+	return tx.Transaction.ToBCSBytes(), authenticator, nil
+}
+
+// MultiAgentAuth contains the authentication data of a multi-agent transaction.
+type MultiAgentAuth struct {
+	// Sender is the account authenticator of the transaction sender.
+	Sender AccountAuthenticator
+	// SecondarySignerAddresses are the addresses of the secondary signers of the transaction.
+	SecondarySignerAddresses [][32]byte
+	// SecondarySigners are the account authenticators of the secondary signers, in the same order as their addresses.
+	SecondarySigners []AccountAuthenticator
+}
+
+// EncodeBSC encodes the multi-agent authentication into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the authentication data.
+func (a MultiAgentAuth) EncodeBSC() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.WriteRawBytes(a.Sender.EncodeBSC())
+	encodeAddresses(bcs, a.SecondarySignerAddresses)
+	bcs.EncodeEnum(uint64(len(a.SecondarySigners)))
+	for _, signer := range a.SecondarySigners {
+		bcs.WriteRawBytes(signer.EncodeBSC())
+	}
+
+	return bcs.GetBytes()
+}
+
+// NewMultiAgentTransaction creates a multi-agent transaction with the provided sender signer, secondary signer
+// addresses and payload. Options are the same as for NewTransaction.
+func (c CedraClient) NewMultiAgentTransaction(ctx context.Context, sender Signer, secondarySignerAddresses [][32]byte, payload Payload, options ...any) (*MultiAgentTransaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, payload, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multi-agent transaction")
+	}
+
+	return NewMultiAgentTransaction(tx, secondarySignerAddresses...), nil
+}
+
+// SubmitMultiAgentTransaction submits a multi-agent transaction signed by the sender and every secondary signer,
+// e.g. with MultiAgentTransaction.SignAs on separate machines.
+// Returns the transaction hash if successful, or an error if submission fails.
+func (c CedraClient) SubmitMultiAgentTransaction(ctx context.Context, tx *MultiAgentTransaction, sender AccountAuthenticator, secondarySigners []AccountAuthenticator) (string, error) {
+	authenticator, err := tx.NewAuthenticator(sender, secondarySigners)
+	if err != nil {
+		return "", errors.Wrap(err, "can't submit transaction")
+	}
+
+	return c.SubmitTransaction(ctx, tx.Transaction.ToBCSBytes(), authenticator)
+}
+
+// decodeMultiAgentAuth reads the authentication data of a multi-agent transaction from the decoder.
+func decodeMultiAgentAuth(bcs *BCSDecoder) (MultiAgentAuth, error) {
+	sender, err := decodeAccountAuthenticator(bcs)
+	if err != nil {
+		return MultiAgentAuth{}, errors.Wrap(err, "can't decode sender authenticator")
+	}
+	secondaryAddresses, err := decodeAddresses(bcs)
+	if err != nil {
+		return MultiAgentAuth{}, errors.Wrap(err, "can't decode secondary signer addresses")
+	}
+	secondarySigners, err := decodeAccountAuthenticators(bcs)
+	if err != nil {
+		return MultiAgentAuth{}, errors.Wrap(err, "can't decode secondary signer authenticators")
+	}
+	if len(secondarySigners) != len(secondaryAddresses) {
+		return MultiAgentAuth{}, errors.New("secondary signer addresses don't match their authenticators")
+	}
+
+	return MultiAgentAuth{
+		Sender:                   sender,
+		SecondarySignerAddresses: secondaryAddresses,
+		SecondarySigners:         secondarySigners,
+	}, nil
+}
+
+// decodeMultiAgentTransaction decodes the fields of multi-agent transaction data that follow its variant.
+func decodeMultiAgentTransaction(bcs *BCSDecoder) (*MultiAgentTransaction, error) {
+	tx, err := decodeTransaction(bcs)
+	if err != nil {
+		return nil, err
+	}
+	secondaryAddresses, err := decodeAddresses(bcs)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode secondary signer addresses")
+	}
+
+	return NewMultiAgentTransaction(&tx, secondaryAddresses...), nil
+}
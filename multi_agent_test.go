@@ -0,0 +1,147 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"testing"
+)
+
+func TestMultiAgentTransactionBCS(t *testing.T) {
+	tx := newTestTransaction(t)
+	first := [32]byte{31: 0x0a}
+	second := [32]byte{31: 0x0b}
+	multiAgentTx := NewMultiAgentTransaction(&tx, first, second)
+
+	// Multi-agent variant, raw transaction, then the two secondary signer addresses.
+	want := append([]byte{0x00}, tx.ToBCSBytes()...)
+	want = append(want, 0x02)
+	want = append(want, first[:]...)
+	want = append(want, second[:]...)
+	if got := multiAgentTx.ToBCSBytes(); !bytes.Equal(got, want) {
+		t.Fatalf("ToBCSBytes() = %x, want %x", got, want)
+	}
+
+	wantMessage := append(mustDecodeHex(t, testTransactionWithDataPrefix), want...)
+	if got := multiAgentTx.SigningMessage(); !bytes.Equal(got, wantMessage) {
+		t.Fatalf("SigningMessage() = %x, want %x", got, wantMessage)
+	}
+
+	bcs := NewBCSDecoder(want[1:])
+	decoded, err := decodeMultiAgentTransaction(bcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bcs.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.SecondarySignerAddresses) != 2 || !bytes.Equal(decoded.ToBCSBytes(), want) {
+		t.Fatalf("decodeMultiAgentTransaction() encodes to %x, want %x", decoded.ToBCSBytes(), want)
+	}
+
+	var unmarshaled MultiAgentTransaction
+	if err := Unmarshal(want, &unmarshaled); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(unmarshaled.ToBCSBytes(), want) {
+		t.Fatalf("Unmarshal() encodes to %x, want %x", unmarshaled.ToBCSBytes(), want)
+	}
+	if err := Unmarshal(append([]byte{0x01}, want[1:]...), &unmarshaled); err == nil {
+		t.Fatal("expected an error for the fee payer variant")
+	}
+}
+
+func TestMultiAgentAuthenticatorBCS(t *testing.T) {
+	sender, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	multiAgentTx := NewMultiAgentTransaction(&tx, secondary.GetAccountAddress())
+
+	senderSignature := bytes.Repeat([]byte{0xaa}, ed25519.SignatureSize)
+	secondarySignature := bytes.Repeat([]byte{0xbb}, ed25519.SignatureSize)
+	senderAuth, err := sender.NewAuthenticator(senderSignature).ToAccountAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondaryAuth, err := secondary.NewAuthenticator(secondarySignature).ToAccountAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Multi-agent variant, the sender's ED25519 account authenticator, then the vectors
+	// of secondary signer addresses and ED25519 account authenticators.
+	secondaryAddress := secondary.GetAccountAddress()
+	want := []byte{0x02, 0x00, 0x20}
+	want = append(want, sender.PublicKey...)
+	want = append(want, 0x40)
+	want = append(want, senderSignature...)
+	want = append(want, 0x01)
+	want = append(want, secondaryAddress[:]...)
+	want = append(want, 0x01, 0x00, 0x20)
+	want = append(want, secondary.PublicKey...)
+	want = append(want, 0x40)
+	want = append(want, secondarySignature...)
+	authenticator, err := multiAgentTx.NewAuthenticator(senderAuth, []AccountAuthenticator{secondaryAuth})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := authenticator.EncodeBSC(); !bytes.Equal(got, want) {
+		t.Fatalf("EncodeBSC() = %x, want %x", got, want)
+	}
+
+	decoded, err := DecodeCedraAuthenticator(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.EncodeBSC(), want) {
+		t.Fatalf("decoded authenticator encodes to %x, want %x", decoded.EncodeBSC(), want)
+	}
+
+	if _, err := multiAgentTx.NewAuthenticator(senderAuth, nil); err == nil {
+		t.Fatal("expected an error for a missing secondary signer")
+	}
+}
+
+func TestMultiAgentTransactionSign(t *testing.T) {
+	secondary, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAccount("0x02" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	multiAgentTx := NewMultiAgentTransaction(&tx, secondary.GetAccountAddress())
+
+	encoded, authenticator, err := multiAgentTx.Sign(context.Background(), other, secondary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, tx.ToBCSBytes()) {
+		t.Fatalf("Sign() encoded %x, want the raw transaction", encoded)
+	}
+	auth, ok := authenticator.Auth.(MultiAgentAuth)
+	if !ok || authenticator.Variant != multiAgentTxVariant || len(auth.SecondarySigners) != 1 {
+		t.Fatalf("Sign() authenticator = %+v, want a multi-agent authenticator with one secondary signer", authenticator)
+	}
+	for name, accountAuth := range map[string]AccountAuthenticator{"sender": auth.Sender, "secondary signer": auth.SecondarySigners[0]} {
+		senderAuth := accountAuth.Auth.(SenderAuth)
+		if !ed25519.Verify(senderAuth.PKey, multiAgentTx.SigningMessage(), senderAuth.Signature) {
+			t.Fatalf("invalid %s signature", name)
+		}
+	}
+
+	if _, _, err := multiAgentTx.Sign(context.Background(), other); err == nil {
+		t.Fatal("expected an error for a missing secondary signer")
+	}
+	if _, err := multiAgentTx.SignAs(context.Background(), other); err == nil {
+		t.Fatal("expected an error for a signer outside the transaction")
+	}
+}
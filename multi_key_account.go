@@ -0,0 +1,535 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"math/bits"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxMultiKeySigners is the maximum number of public keys of a K-of-N account.
+	maxMultiKeySigners = 32
+	// multiSignatureBitmapSize is the size of the bitmap identifying the keys that signed a multi-signature.
+	multiSignatureBitmapSize = 4
+)
+
+// PartialSignature is the signature of a message by one of the keys of a K-of-N account.
+// Partial signatures of at least the threshold number of keys are combined into a multi-signature.
+type PartialSignature struct {
+	// Index is the position of the signing key in the public keys of the account.
+	Index uint8
+	// Signature is the signature of the message by the key.
+	Signature []byte
+}
+
+// MultiEd25519Account represents a K-of-N account controlled by ED25519 keys:
+// a transaction is authorized once Threshold of the PublicKeys have signed it.
+// It implements Signer, signing with the locally held Signers of its keys; when the keys are held
+// by separate parties, collect their signatures with SignPartial and combine them with NewMultiAuthenticator.
+type MultiEd25519Account struct {
+	// AccountAddress is the 32-byte account address derived from the public keys and the threshold.
+	AccountAddress [32]byte
+	// PublicKeys are the ED25519 public keys of the account, in order.
+	PublicKeys []ed25519.PublicKey
+	// Threshold is the number of signatures required to authorize a transaction.
+	Threshold uint8
+	// Signers are the signers of the keys available locally, used by Sign.
+	Signers []Signer
+}
+
+// NewMultiEd25519Account creates a K-of-N ED25519 account from its public keys and threshold.
+// The optional signers must sign with keys of the account; they are used by Sign.
+// Returns an error if the threshold or a public key is invalid, or if a signer doesn't belong to the account.
+func NewMultiEd25519Account(publicKeys []ed25519.PublicKey, threshold uint8, signers ...Signer) (MultiEd25519Account, error) {
+	if err := validateMultiKeyThreshold(len(publicKeys), threshold); err != nil {
+		return MultiEd25519Account{}, errors.Wrap(err, "can't create multi ed25519 account")
+	}
+	for i, publicKey := range publicKeys {
+		if len(publicKey) != ed25519.PublicKeySize {
+			return MultiEd25519Account{}, errors.Errorf("can't create multi ed25519 account: invalid public key at index %d", i)
+		}
+	}
+
+	account := MultiEd25519Account{
+		PublicKeys: publicKeys,
+		Threshold:  threshold,
+		Signers:    signers,
+	}
+	for _, signer := range signers {
+		if _, err := account.keyIndex(signer); err != nil {
+			return MultiEd25519Account{}, errors.Wrap(err, "can't create multi ed25519 account")
+		}
+	}
+	account.AccountAddress = deriveAccountAddress(account.GetPublicKey(), multiEd25519Schema)
+
+	return account, nil
+}
+
+// GetAccountAddress returns the 32-byte account address.
+func (a MultiEd25519Account) GetAccountAddress() [32]byte {
+	return a.AccountAddress
+}
+
+// GetAccountAddressString returns the hexadecimal string representation of the account address.
+func (a MultiEd25519Account) GetAccountAddressString() string {
+	return hex.EncodeToString(a.AccountAddress[:])
+}
+
+// GetPublicKey returns the multi ED25519 public key of the account: the concatenated public keys followed by the threshold.
+func (a MultiEd25519Account) GetPublicKey() []byte {
+	publicKey := make([]byte, 0, len(a.PublicKeys)*ed25519.PublicKeySize+1)
+	for _, key := range a.PublicKeys {
+		publicKey = append(publicKey, key...)
+	}
+
+	return append(publicKey, a.Threshold)
+}
+
+// KeyScheme returns KeySchemeMultiEd25519.
+func (a MultiEd25519Account) KeyScheme() KeyScheme {
+	return KeySchemeMultiEd25519
+}
+
+// Sign signs the message with the locally held Signers until the threshold is reached
+// and returns the encoded multi-signature.
+// Returns an error if the Signers hold fewer keys than the threshold.
+func (a MultiEd25519Account) Sign(message []byte) ([]byte, error) {
+	return a.SignContext(context.Background(), message)
+}
+
+// SignContext is like Sign, with a context cancelling the signing of ContextSigner signers.
+func (a MultiEd25519Account) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	signatures, err := collectPartialSignatures(ctx, a.Signers, a.Threshold, message, a.SignPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.EncodeSignature(signatures)
+}
+
+// SignPartial signs the message with a signer holding one of the keys of the account.
+// The context cancels the signing if the signer is a ContextSigner.
+// Returns an error if the signer doesn't belong to the account or produces an invalid signature.
+func (a MultiEd25519Account) SignPartial(ctx context.Context, signer Signer, message []byte) (PartialSignature, error) {
+	index, err := a.keyIndex(signer)
+	if err != nil {
+		return PartialSignature{}, errors.Wrap(err, "can't sign message")
+	}
+	signature, err := signMessage(ctx, signer, message)
+	if err != nil {
+		return PartialSignature{}, errors.Wrapf(err, "can't sign message with key %d", index)
+	}
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(a.PublicKeys[index], message, signature) {
+		return PartialSignature{}, errors.Errorf("can't sign message: invalid signature of key %d", index)
+	}
+
+	return PartialSignature{Index: index, Signature: signature}, nil
+}
+
+// EncodeSignature combines partial signatures into a multi ED25519 signature:
+// the signatures ordered by key index followed by the bitmap of the signing keys.
+// Returns an error if there are fewer signatures than the threshold or a key signed twice.
+func (a MultiEd25519Account) EncodeSignature(signatures []PartialSignature) ([]byte, error) {
+	signatures, bitmap, err := sortPartialSignatures(signatures, len(a.PublicKeys), a.Threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't encode multi ed25519 signature")
+	}
+
+	signature := make([]byte, 0, len(signatures)*ed25519.SignatureSize+multiSignatureBitmapSize)
+	for _, partial := range signatures {
+		if len(partial.Signature) != ed25519.SignatureSize {
+			return nil, errors.Errorf("can't encode multi ed25519 signature: invalid signature of key %d", partial.Index)
+		}
+		signature = append(signature, partial.Signature...)
+	}
+
+	return append(signature, bitmap[:]...), nil
+}
+
+// Verify reports whether the signature is a valid multi ED25519 signature of the message,
+// as produced by Sign or EncodeSignature, by at least Threshold keys of the account.
+func (a MultiEd25519Account) Verify(message []byte, signature []byte) bool {
+	if len(signature) < multiSignatureBitmapSize || (len(signature)-multiSignatureBitmapSize)%ed25519.SignatureSize != 0 {
+		return false
+	}
+	bitmap := signature[len(signature)-multiSignatureBitmapSize:]
+	signatures := signature[:len(signature)-multiSignatureBitmapSize]
+	if len(signatures)/ed25519.SignatureSize < int(a.Threshold) {
+		return false
+	}
+
+	next := 0
+	for index, key := range a.PublicKeys {
+		if bitmap[index/8]&(byte(0x80)>>(index%8)) == 0 {
+			continue
+		}
+		if next == len(signatures) || !ed25519.Verify(key, message, signatures[next:next+ed25519.SignatureSize]) {
+			return false
+		}
+		next += ed25519.SignatureSize
+	}
+	signers := 0
+	for _, b := range bitmap {
+		signers += bits.OnesCount8(b)
+	}
+
+	return next == len(signatures) && signers*ed25519.SignatureSize == next
+}
+
+// NewAuthenticator creates a multi ED25519 transaction authenticator for a signature produced by Sign or EncodeSignature.
+func (a MultiEd25519Account) NewAuthenticator(signature []byte) CedraAuthenticator {
+	return CedraAuthenticator{
+		Variant: multiEd25519TxVariant,
+		Auth:    NewSenderAuth(a.GetPublicKey(), signature),
+	}
+}
+
+// SimulationSignature returns a multi ED25519 signature of zeroed signatures by the first Threshold keys.
+func (a MultiEd25519Account) SimulationSignature() []byte {
+	signatures := make([]PartialSignature, 0, a.Threshold)
+	for i := range a.Threshold {
+		signatures = append(signatures, PartialSignature{Index: i, Signature: make([]byte, ed25519.SignatureSize)})
+	}
+	signature, _ := a.EncodeSignature(signatures)
+
+	return signature
+}
+
+// NewMultiAuthenticator combines partial signatures into a multi ED25519 transaction authenticator.
+func (a MultiEd25519Account) NewMultiAuthenticator(signatures []PartialSignature) (CedraAuthenticator, error) {
+	signature, err := a.EncodeSignature(signatures)
+	if err != nil {
+		return CedraAuthenticator{}, err
+	}
+
+	return a.NewAuthenticator(signature), nil
+}
+
+// keyIndex returns the position of the signer's key in the public keys of the account.
+func (a MultiEd25519Account) keyIndex(signer Signer) (uint8, error) {
+	if signer.KeyScheme() != KeySchemeEd25519 {
+		return 0, errors.New("signer doesn't use an ed25519 key")
+	}
+	index := slices.IndexFunc(a.PublicKeys, func(key ed25519.PublicKey) bool {
+		return bytes.Equal(key, signer.GetPublicKey())
+	})
+	if index < 0 {
+		return 0, errors.New("signer key doesn't belong to the account")
+	}
+
+	return uint8(index), nil
+}
+
+// MultiKeyAccount represents a K-of-N account controlled by keys of any scheme (e.g. ED25519 and secp256k1):
+// a transaction is authorized once SignaturesRequired of the PublicKeys have signed it.
+// It implements Signer, signing with the locally held Signers of its keys; when the keys are held
+// by separate parties, collect their signatures with SignPartial and combine them with NewMultiAuthenticator.
+type MultiKeyAccount struct {
+	// AccountAddress is the 32-byte account address derived from the public keys and the number of required signatures.
+	AccountAddress [32]byte
+	// PublicKeys are the public keys of the account, in order.
+	PublicKeys []AnyPublicKey
+	// SignaturesRequired is the number of signatures required to authorize a transaction.
+	SignaturesRequired uint8
+	// Signers are the signers of the keys available locally, used by Sign.
+	Signers []Signer
+}
+
+// NewMultiKeyAccount creates a K-of-N multi-key account from its public keys and the number of required signatures.
+// Use NewAnyPublicKey to get the public key of a Signer. The optional signers must sign with keys of the account;
+// they are used by Sign.
+// Returns an error if the number of required signatures is invalid or if a signer doesn't belong to the account.
+func NewMultiKeyAccount(publicKeys []AnyPublicKey, signaturesRequired uint8, signers ...Signer) (MultiKeyAccount, error) {
+	if err := validateMultiKeyThreshold(len(publicKeys), signaturesRequired); err != nil {
+		return MultiKeyAccount{}, errors.Wrap(err, "can't create multi-key account")
+	}
+
+	account := MultiKeyAccount{
+		PublicKeys:         publicKeys,
+		SignaturesRequired: signaturesRequired,
+		Signers:            signers,
+	}
+	for _, signer := range signers {
+		if _, err := account.keyIndex(signer); err != nil {
+			return MultiKeyAccount{}, errors.Wrap(err, "can't create multi-key account")
+		}
+	}
+	account.AccountAddress = deriveAccountAddress(account.GetPublicKey(), multiKeySchema)
+
+	return account, nil
+}
+
+// GetAccountAddress returns the 32-byte account address.
+func (a MultiKeyAccount) GetAccountAddress() [32]byte {
+	return a.AccountAddress
+}
+
+// GetAccountAddressString returns the hexadecimal string representation of the account address.
+func (a MultiKeyAccount) GetAccountAddressString() string {
+	return hex.EncodeToString(a.AccountAddress[:])
+}
+
+// GetPublicKey returns the BCS-encoded multi-key public key of the account:
+// the scheme-tagged public keys followed by the number of required signatures.
+func (a MultiKeyAccount) GetPublicKey() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(uint64(len(a.PublicKeys)))
+	for _, key := range a.PublicKeys {
+		bcs.WriteRawBytes(key.ToBCSBytes())
+	}
+	bcs.WriteRawBytes(EncodeUintToBCS(a.SignaturesRequired))
+
+	return bcs.GetBytes()
+}
+
+// KeyScheme returns KeySchemeMultiKey.
+func (a MultiKeyAccount) KeyScheme() KeyScheme {
+	return KeySchemeMultiKey
+}
+
+// Sign signs the message with the locally held Signers until the number of required signatures is reached
+// and returns the encoded multi-key signature.
+// Returns an error if the Signers hold fewer keys than the number of required signatures.
+func (a MultiKeyAccount) Sign(message []byte) ([]byte, error) {
+	return a.SignContext(context.Background(), message)
+}
+
+// SignContext is like Sign, with a context cancelling the signing of ContextSigner signers.
+func (a MultiKeyAccount) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	signatures, err := collectPartialSignatures(ctx, a.Signers, a.SignaturesRequired, message, a.SignPartial)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.EncodeSignature(signatures)
+}
+
+// SignPartial signs the message with a signer holding one of the keys of the account.
+// The context cancels the signing if the signer is a ContextSigner.
+// Returns an error if the signer doesn't belong to the account or produces an invalid signature.
+func (a MultiKeyAccount) SignPartial(ctx context.Context, signer Signer, message []byte) (PartialSignature, error) {
+	index, err := a.keyIndex(signer)
+	if err != nil {
+		return PartialSignature{}, errors.Wrap(err, "can't sign message")
+	}
+	signature, err := signMessage(ctx, signer, message)
+	if err != nil {
+		return PartialSignature{}, errors.Wrapf(err, "can't sign message with key %d", index)
+	}
+	if !a.PublicKeys[index].Verify(message, signature) {
+		return PartialSignature{}, errors.Errorf("can't sign message: invalid signature of key %d", index)
+	}
+
+	return PartialSignature{Index: index, Signature: signature}, nil
+}
+
+// EncodeSignature combines partial signatures into a BCS-encoded multi-key signature:
+// the scheme-tagged signatures ordered by key index followed by the bitmap of the signing keys.
+// Returns an error if there are fewer signatures than required or a key signed twice.
+func (a MultiKeyAccount) EncodeSignature(signatures []PartialSignature) ([]byte, error) {
+	signatures, bitmap, err := sortPartialSignatures(signatures, len(a.PublicKeys), a.SignaturesRequired)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't encode multi-key signature")
+	}
+
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(uint64(len(signatures)))
+	for _, partial := range signatures {
+		bcs.EncodeEnum(a.PublicKeys[partial.Index].Variant)
+		bcs.EncodeBytes(partial.Signature)
+	}
+	bcs.EncodeBytes(bitmap[:])
+
+	return bcs.GetBytes(), nil
+}
+
+// Verify reports whether the signature is a valid multi-key signature of the message,
+// as produced by Sign or EncodeSignature, by at least SignaturesRequired keys of the account.
+func (a MultiKeyAccount) Verify(message []byte, signature []byte) bool {
+	bcs := NewBCSDecoder(signature)
+	length, err := bcs.DecodeLength()
+	if err != nil || length < int(a.SignaturesRequired) {
+		return false
+	}
+	signatures := make([]AnyPublicKey, length)
+	for i := range signatures {
+		// A scheme-tagged signature has the layout of a scheme-tagged public key.
+		if err := signatures[i].UnmarshalBCS(bcs); err != nil {
+			return false
+		}
+	}
+	bitmap, err := bcs.DecodeBytes()
+	if err != nil || len(bitmap) != multiSignatureBitmapSize || bcs.Finish() != nil {
+		return false
+	}
+
+	next := 0
+	for index, key := range a.PublicKeys {
+		if bitmap[index/8]&(byte(0x80)>>(index%8)) == 0 {
+			continue
+		}
+		if next == len(signatures) || signatures[next].Variant != key.Variant || !key.Verify(message, signatures[next].PKey) {
+			return false
+		}
+		next++
+	}
+	signers := 0
+	for _, b := range bitmap {
+		signers += bits.OnesCount8(b)
+	}
+
+	return next == len(signatures) && signers == next
+}
+
+// NewAuthenticator creates a multi-key transaction authenticator for a signature produced by Sign or EncodeSignature.
+func (a MultiKeyAccount) NewAuthenticator(signature []byte) CedraAuthenticator {
+	return CedraAuthenticator{
+		Variant: singleSenderTxVariant,
+		Auth: AccountAuthenticator{
+			Variant: accountAuthMultiKeyVariant,
+			Auth: MultiKeyAuth{
+				PKey:      a.GetPublicKey(),
+				Signature: signature,
+			},
+		},
+	}
+}
+
+// SimulationSignature returns a multi-key signature of zeroed signatures by the first SignaturesRequired keys,
+// sized for the scheme of each key.
+func (a MultiKeyAccount) SimulationSignature() []byte {
+	signatures := make([]PartialSignature, 0, a.SignaturesRequired)
+	for i := range a.SignaturesRequired {
+		size := ed25519.SignatureSize
+		if int(i) < len(a.PublicKeys) && a.PublicKeys[i].Variant == anyKeySecp256k1Variant {
+			size = secp256k1SignatureSize
+		}
+		signatures = append(signatures, PartialSignature{Index: i, Signature: make([]byte, size)})
+	}
+	signature, _ := a.EncodeSignature(signatures)
+
+	return signature
+}
+
+// NewMultiAuthenticator combines partial signatures into a multi-key transaction authenticator.
+func (a MultiKeyAccount) NewMultiAuthenticator(signatures []PartialSignature) (CedraAuthenticator, error) {
+	signature, err := a.EncodeSignature(signatures)
+	if err != nil {
+		return CedraAuthenticator{}, err
+	}
+
+	return a.NewAuthenticator(signature), nil
+}
+
+// keyIndex returns the position of the signer's key in the public keys of the account.
+func (a MultiKeyAccount) keyIndex(signer Signer) (uint8, error) {
+	publicKey, err := NewAnyPublicKey(signer)
+	if err != nil {
+		return 0, err
+	}
+	index := slices.IndexFunc(a.PublicKeys, func(key AnyPublicKey) bool {
+		return key.Variant == publicKey.Variant && bytes.Equal(key.PKey, publicKey.PKey)
+	})
+	if index < 0 {
+		return 0, errors.New("signer key doesn't belong to the account")
+	}
+
+	return uint8(index), nil
+}
+
+// decodeMultiKeyPublicKey decodes a multi-key public key encoded by MultiKeyAccount.GetPublicKey
+// into an account without signers.
+func decodeMultiKeyPublicKey(publicKey []byte) (MultiKeyAccount, error) {
+	bcs := NewBCSDecoder(publicKey)
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return MultiKeyAccount{}, errors.Wrap(err, "can't decode public keys")
+	}
+	publicKeys := make([]AnyPublicKey, length)
+	for i := range publicKeys {
+		if err := publicKeys[i].UnmarshalBCS(bcs); err != nil {
+			return MultiKeyAccount{}, errors.Wrapf(err, "can't decode public key %d", i)
+		}
+	}
+	signaturesRequired, err := bcs.DecodeUint8()
+	if err != nil {
+		return MultiKeyAccount{}, errors.Wrap(err, "can't decode signatures required")
+	}
+	if err := bcs.Finish(); err != nil {
+		return MultiKeyAccount{}, err
+	}
+
+	return NewMultiKeyAccount(publicKeys, signaturesRequired)
+}
+
+// validateMultiKeyThreshold checks the number of keys and the threshold of a K-of-N account.
+func validateMultiKeyThreshold(keyCount int, threshold uint8) error {
+	if keyCount == 0 || keyCount > maxMultiKeySigners {
+		return errors.Errorf("number of public keys must be between 1 and %d, got %d", maxMultiKeySigners, keyCount)
+	}
+	if threshold == 0 || int(threshold) > keyCount {
+		return errors.Errorf("threshold must be between 1 and %d, got %d", keyCount, threshold)
+	}
+
+	return nil
+}
+
+// collectPartialSignatures signs the message with the signers until the threshold is reached.
+func collectPartialSignatures(
+	ctx context.Context,
+	signers []Signer,
+	threshold uint8,
+	message []byte,
+	signPartial func(context.Context, Signer, []byte) (PartialSignature, error),
+) ([]PartialSignature, error) {
+	signatures := make([]PartialSignature, 0, threshold)
+	for _, signer := range signers {
+		if len(signatures) == int(threshold) {
+			break
+		}
+		signature, err := signPartial(ctx, signer, message)
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, signature)
+	}
+	if len(signatures) < int(threshold) {
+		return nil, errors.Errorf("can't sign message: %d signers available, %d signatures required", len(signatures), threshold)
+	}
+
+	return signatures, nil
+}
+
+// sortPartialSignatures checks the partial signatures of an account with keyCount keys
+// and returns them ordered by key index, together with the bitmap of the signing keys.
+// In the bitmap the key at index i is the bit (7 - i%8) of byte i/8.
+func sortPartialSignatures(signatures []PartialSignature, keyCount int, threshold uint8) ([]PartialSignature, [multiSignatureBitmapSize]byte, error) {
+	var bitmap [multiSignatureBitmapSize]byte
+	if len(signatures) < int(threshold) {
+		return nil, bitmap, errors.Errorf("%d signatures provided, %d required", len(signatures), threshold)
+	}
+
+	sorted := slices.Clone(signatures)
+	slices.SortFunc(sorted, func(a, b PartialSignature) int {
+		return int(a.Index) - int(b.Index)
+	})
+	for _, signature := range sorted {
+		if int(signature.Index) >= keyCount {
+			return nil, bitmap, errors.Errorf("signature key index %d out of range", signature.Index)
+		}
+		bit := byte(0x80) >> (signature.Index % 8)
+		if bitmap[signature.Index/8]&bit != 0 {
+			return nil, bitmap, errors.Errorf("duplicate signature of key %d", signature.Index)
+		}
+		bitmap[signature.Index/8] |= bit
+	}
+
+	return sorted, bitmap, nil
+}
@@ -0,0 +1,171 @@
+package cedra
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+)
+
+// newTestMultiEd25519Account returns a 2-of-3 multi ED25519 account whose first and last keys are held locally.
+func newTestMultiEd25519Account(t *testing.T) MultiEd25519Account {
+	t.Helper()
+	signers := make([]Signer, 0, 3)
+	publicKeys := make([]ed25519.PublicKey, 0, 3)
+	for _, key := range []string{
+		testPrivateKey,
+		"0x01" + "00000000000000000000000000000000000000000000000000000000000000",
+		"0x02" + "00000000000000000000000000000000000000000000000000000000000000",
+	} {
+		account, err := NewAccount(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signers = append(signers, account)
+		publicKeys = append(publicKeys, account.PublicKey)
+	}
+	multiEd25519, err := NewMultiEd25519Account(publicKeys, 2, signers[0], signers[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return multiEd25519
+}
+
+func TestMultiEd25519AccountAddress(t *testing.T) {
+	publicKeys := []ed25519.PublicKey{
+		bytes.Repeat([]byte{0x11}, ed25519.PublicKeySize),
+		bytes.Repeat([]byte{0x22}, ed25519.PublicKeySize),
+		bytes.Repeat([]byte{0x33}, ed25519.PublicKeySize),
+	}
+	multiEd25519, err := NewMultiEd25519Account(publicKeys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The public key is the concatenated keys followed by the threshold.
+	wantPublicKey := append(bytes.Join([][]byte{publicKeys[0], publicKeys[1], publicKeys[2]}, nil), 0x02)
+	if !bytes.Equal(multiEd25519.GetPublicKey(), wantPublicKey) {
+		t.Fatalf("GetPublicKey() = %x, want %x", multiEd25519.GetPublicKey(), wantPublicKey)
+	}
+	// sha3-256 of the public key followed by the multi ED25519 scheme byte.
+	want := "2aa6537955f6bb726832716fc11c81b8e06dae1d2107313a35a6b4fedd01d658"
+	if got := multiEd25519.GetAccountAddressString(); got != want {
+		t.Fatalf("GetAccountAddressString() = %s, want %s", got, want)
+	}
+}
+
+func TestNewMultiEd25519AccountRejectsInvalidKeys(t *testing.T) {
+	keys := func(n int) []ed25519.PublicKey {
+		publicKeys := make([]ed25519.PublicKey, n)
+		for i := range publicKeys {
+			publicKeys[i] = bytes.Repeat([]byte{byte(i + 1)}, ed25519.PublicKeySize)
+		}
+
+		return publicKeys
+	}
+	other, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		publicKeys []ed25519.PublicKey
+		threshold  uint8
+		signers    []Signer
+	}{
+		{name: "threshold 0", publicKeys: keys(3), threshold: 0},
+		{name: "threshold above the number of keys", publicKeys: keys(3), threshold: 4},
+		{name: "no keys", publicKeys: nil, threshold: 1},
+		{name: "more than 32 keys", publicKeys: keys(33), threshold: 1},
+		{name: "invalid public key", publicKeys: append(keys(2), []byte{0x01}), threshold: 1},
+		{name: "signer outside the account", publicKeys: keys(3), threshold: 2, signers: []Signer{other}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewMultiEd25519Account(tt.publicKeys, tt.threshold, tt.signers...); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	if _, err := NewMultiEd25519Account(keys(32), 32); err != nil {
+		t.Fatalf("NewMultiEd25519Account() with 32 keys: %v", err)
+	}
+}
+
+func TestMultiEd25519AccountEncodeSignature(t *testing.T) {
+	publicKeys := make([]ed25519.PublicKey, maxMultiKeySigners)
+	for i := range publicKeys {
+		publicKeys[i] = bytes.Repeat([]byte{byte(i + 1)}, ed25519.PublicKeySize)
+	}
+	multiEd25519, err := NewMultiEd25519Account(publicKeys, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partial := func(index uint8) PartialSignature {
+		return PartialSignature{Index: index, Signature: bytes.Repeat([]byte{index}, ed25519.SignatureSize)}
+	}
+
+	// Out of order partial signatures are sorted by key index, and the bitmap has
+	// the key at index i in bit 7 - i%8 of byte i/8.
+	signature, err := multiEd25519.EncodeSignature([]PartialSignature{partial(31), partial(9), partial(0), partial(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := bytes.Join([][]byte{
+		partial(0).Signature,
+		partial(2).Signature,
+		partial(9).Signature,
+		partial(31).Signature,
+		{0xa0, 0x40, 0x00, 0x01},
+	}, nil)
+	if !bytes.Equal(signature, want) {
+		t.Fatalf("EncodeSignature() = %x, want %x", signature, want)
+	}
+
+	if _, err := multiEd25519.EncodeSignature([]PartialSignature{partial(0)}); err == nil {
+		t.Fatal("expected an error for fewer signatures than the threshold")
+	}
+	if _, err := multiEd25519.EncodeSignature([]PartialSignature{partial(1), partial(1)}); err == nil {
+		t.Fatal("expected an error for a key signing twice")
+	}
+}
+
+func TestMultiEd25519AccountVerify(t *testing.T) {
+	multiEd25519 := newTestMultiEd25519Account(t)
+	message := []byte("message")
+	signature, err := multiEd25519.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(signature[len(signature)-multiSignatureBitmapSize:]); got != "a0000000" {
+		t.Fatalf("bitmap = %s, want a0000000", got)
+	}
+	if !multiEd25519.Verify(message, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	if multiEd25519.Verify([]byte("other"), signature) {
+		t.Fatal("signature of another message accepted")
+	}
+	if multiEd25519.Verify(message, multiEd25519.SimulationSignature()) {
+		t.Fatal("zeroed signature accepted")
+	}
+	if multiEd25519.Verify(message, append(bytes.Clone(signature), 0)) {
+		t.Fatal("signature with trailing bytes accepted")
+	}
+
+	// Marking another key in the bitmap attributes the signatures to the wrong keys.
+	wrongBitmap := bytes.Clone(signature)
+	wrongBitmap[len(wrongBitmap)-multiSignatureBitmapSize] = 0xc0
+	if multiEd25519.Verify(message, wrongBitmap) {
+		t.Fatal("signature with a wrong bitmap accepted")
+	}
+	// A single partial signature is below the threshold.
+	single := append(bytes.Clone(signature[:ed25519.SignatureSize]), 0x80, 0x00, 0x00, 0x00)
+	if multiEd25519.Verify(message, single) {
+		t.Fatal("signature below the threshold accepted")
+	}
+}
@@ -0,0 +1,221 @@
+package cedra
+
+import (
+	"context"
+	"crypto/sha3"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// multisigPayloadVariant is the variant identifier for multisig payloads.
+	multisigPayloadVariant = 3
+	// multisigEntryFunctionVariant is the variant identifier for entry functions inside multisig payloads.
+	multisigEntryFunctionVariant = 0
+	// multisigAccountModule is the framework module managing multisig accounts.
+	multisigAccountModule = "multisig_account"
+)
+
+// MultisigPayload represents the payload that executes a transaction of an on-chain multisig account.
+// The transaction must have been created and approved by enough owners beforehand.
+type MultisigPayload struct {
+	// MultisigAddress is the address of the multisig account.
+	MultisigAddress [32]byte
+	// Payload is the entry function to execute. It can be nil if the full payload was stored
+	// on-chain when the multisig transaction was created; it is required if only its hash was stored.
+	Payload *TransactionPayload
+}
+
+// ToBCSBytes encodes the multisig payload into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the payload.
+func (p *MultisigPayload) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(multisigPayloadVariant)
+	bcs.WriteRawBytes(p.MultisigAddress[:])
+	if p.Payload == nil {
+		bcs.WriteRawBytes([]byte{0})
+
+		return bcs.GetBytes()
+	}
+	bcs.WriteRawBytes([]byte{1})
+	bcs.WriteRawBytes(EncodeMultisigTransactionPayload(p.Payload))
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes a multisig payload encoded with ToBCSBytes.
+func (p *MultisigPayload) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(multisigPayloadVariant, "multisig payload"); err != nil {
+		return err
+	}
+	payload, err := decodeMultisigPayload(bcs)
+	if err != nil {
+		return err
+	}
+	*p = payload
+
+	return nil
+}
+
+// EncodeMultisigTransactionPayload encodes an entry function as a multisig transaction payload.
+// This is the payload stored on-chain by 0x1::multisig_account::create_transaction.
+func EncodeMultisigTransactionPayload(payload *TransactionPayload) []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(multisigEntryFunctionVariant)
+	payload.encodeEntryFunction(bcs)
+
+	return bcs.GetBytes()
+}
+
+// NewMultisigCreateTransactionPayload creates a 0x1::multisig_account::create_transaction payload
+// that proposes the entry function for execution by the multisig account.
+func NewMultisigCreateTransactionPayload(multisigAddress [32]byte, payload *TransactionPayload) *TransactionPayload {
+	return newMultisigAccountPayload("create_transaction",
+		multisigAddress[:],
+		EncodeToBCSBytes(EncodeMultisigTransactionPayload(payload)),
+	)
+}
+
+// NewMultisigCreateTransactionWithHashPayload creates a 0x1::multisig_account::create_transaction_with_hash payload
+// that proposes the entry function by its hash only. The full payload must then be provided on execution.
+func NewMultisigCreateTransactionWithHashPayload(multisigAddress [32]byte, payload *TransactionPayload) *TransactionPayload {
+	hash := sha3.Sum256(EncodeMultisigTransactionPayload(payload))
+
+	return newMultisigAccountPayload("create_transaction_with_hash",
+		multisigAddress[:],
+		EncodeToBCSBytes(hash[:]),
+	)
+}
+
+// NewMultisigApprovePayload creates a 0x1::multisig_account::approve_transaction payload
+// that approves the pending multisig transaction with the given sequence number.
+func NewMultisigApprovePayload(multisigAddress [32]byte, sequenceNumber uint64) *TransactionPayload {
+	return newMultisigAccountPayload("approve_transaction",
+		multisigAddress[:],
+		EncodeUintToBCS(sequenceNumber),
+	)
+}
+
+// NewMultisigRejectPayload creates a 0x1::multisig_account::reject_transaction payload
+// that rejects the pending multisig transaction with the given sequence number.
+func NewMultisigRejectPayload(multisigAddress [32]byte, sequenceNumber uint64) *TransactionPayload {
+	return newMultisigAccountPayload("reject_transaction",
+		multisigAddress[:],
+		EncodeUintToBCS(sequenceNumber),
+	)
+}
+
+// NewMultisigExecuteRejectedPayload creates a 0x1::multisig_account::execute_rejected_transaction payload
+// that removes the next pending multisig transaction once it has been rejected by enough owners.
+func NewMultisigExecuteRejectedPayload(multisigAddress [32]byte) *TransactionPayload {
+	return newMultisigAccountPayload("execute_rejected_transaction", multisigAddress[:])
+}
+
+// newMultisigAccountPayload creates an entry function payload calling the multisig account framework module.
+func newMultisigAccountPayload(function string, arguments ...[]byte) *TransactionPayload {
+	return &TransactionPayload{
+		ModuleAddress: cedraFrameworkAddress,
+		ModuleName:    multisigAccountModule,
+		FunctionName:  function,
+		Arguments:     arguments,
+	}
+}
+
+// NewMultisigCreateTransaction creates a transaction in which the sender, an owner of the multisig account,
+// proposes the entry function for execution by the multisig account.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewMultisigCreateTransaction(ctx context.Context, sender Signer, multisigAddress [32]byte, payload *TransactionPayload, options ...any) (*Transaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, NewMultisigCreateTransactionPayload(multisigAddress, payload), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multisig transaction")
+	}
+
+	return tx, nil
+}
+
+// NewMultisigApproveTransaction creates a transaction in which the sender, an owner of the multisig account,
+// approves the pending multisig transaction with the given sequence number.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewMultisigApproveTransaction(ctx context.Context, sender Signer, multisigAddress [32]byte, sequenceNumber uint64, options ...any) (*Transaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, NewMultisigApprovePayload(multisigAddress, sequenceNumber), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multisig approve transaction")
+	}
+
+	return tx, nil
+}
+
+// NewMultisigRejectTransaction creates a transaction in which the sender, an owner of the multisig account,
+// rejects the pending multisig transaction with the given sequence number.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewMultisigRejectTransaction(ctx context.Context, sender Signer, multisigAddress [32]byte, sequenceNumber uint64, options ...any) (*Transaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, NewMultisigRejectPayload(multisigAddress, sequenceNumber), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multisig reject transaction")
+	}
+
+	return tx, nil
+}
+
+// NewMultisigExecuteTransaction creates a transaction in which the sender, an owner of the multisig account,
+// executes the next pending multisig transaction once it has been approved by enough owners.
+// The payload can be nil if the full payload was stored on-chain when the transaction was created.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewMultisigExecuteTransaction(ctx context.Context, sender Signer, multisigAddress [32]byte, payload *TransactionPayload, options ...any) (*Transaction, error) {
+	multisigPayload := &MultisigPayload{
+		MultisigAddress: multisigAddress,
+		Payload:         payload,
+	}
+	tx, err := c.NewTransaction(ctx, sender, multisigPayload, options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multisig execute transaction")
+	}
+
+	return tx, nil
+}
+
+// NewMultisigExecuteRejectedTransaction creates a transaction in which the sender, an owner of the multisig account,
+// removes the next pending multisig transaction once it has been rejected by enough owners.
+// Options are the same as for NewTransaction.
+func (c CedraClient) NewMultisigExecuteRejectedTransaction(ctx context.Context, sender Signer, multisigAddress [32]byte, options ...any) (*Transaction, error) {
+	tx, err := c.NewTransaction(ctx, sender, NewMultisigExecuteRejectedPayload(multisigAddress), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create multisig execute rejected transaction")
+	}
+
+	return tx, nil
+}
+
+// decodeMultisigPayload decodes the fields of a multisig payload that follow its variant.
+func decodeMultisigPayload(bcs *BCSDecoder) (MultisigPayload, error) {
+	multisigAddress, err := bcs.DecodeAddress()
+	if err != nil {
+		return MultisigPayload{}, errors.Wrap(err, "can't decode multisig address")
+	}
+	hasPayload, err := bcs.DecodeBool()
+	if err != nil {
+		return MultisigPayload{}, errors.Wrap(err, "can't decode multisig payload option")
+	}
+	if !hasPayload {
+		return MultisigPayload{MultisigAddress: multisigAddress}, nil
+	}
+
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return MultisigPayload{}, errors.Wrap(err, "can't decode multisig payload variant")
+	}
+	if variant != multisigEntryFunctionVariant {
+		return MultisigPayload{}, errors.Errorf("can't decode multisig payload: unsupported variant %d", variant)
+	}
+	payload, err := decodeTransactionPayload(bcs)
+	if err != nil {
+		return MultisigPayload{}, errors.Wrap(err, "can't decode multisig payload")
+	}
+
+	return MultisigPayload{
+		MultisigAddress: multisigAddress,
+		Payload:         &payload,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package cedra
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestMultisigPayloadBCS(t *testing.T) {
+	multisigAddress := [32]byte{31: 0x55}
+	entryFunction := &TransactionPayload{
+		ModuleAddress: cedraFrameworkAddress,
+		ModuleName:    "coin",
+		FunctionName:  "burn",
+		TypeArguments: []TypeTag{U8Tag},
+		Arguments:     [][]byte{{0x2a}},
+	}
+
+	// Multisig variant, multisig address, then the Option of the entry function.
+	prefix := append([]byte{0x03}, multisigAddress[:]...)
+	withPayload := append(bytes.Clone(prefix), 0x01, 0x00)
+	withPayload = append(withPayload, cedraFrameworkAddress[:]...)
+	withPayload = append(withPayload, 0x04, 'c', 'o', 'i', 'n', 0x04, 'b', 'u', 'r', 'n')
+	withPayload = append(withPayload, 0x01, 0x01, 0x01, 0x01, 0x2a)
+
+	tests := []struct {
+		name    string
+		payload *MultisigPayload
+		want    []byte
+	}{
+		{
+			name:    "without payload",
+			payload: &MultisigPayload{MultisigAddress: multisigAddress},
+			want:    append(bytes.Clone(prefix), 0x00),
+		},
+		{
+			name:    "with payload",
+			payload: &MultisigPayload{MultisigAddress: multisigAddress, Payload: entryFunction},
+			want:    withPayload,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.payload.ToBCSBytes(); !bytes.Equal(got, tt.want) {
+				t.Fatalf("ToBCSBytes() = %x, want %x", got, tt.want)
+			}
+
+			bcs := NewBCSDecoder(tt.want)
+			decoded, err := decodePayload(bcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bcs.Finish(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(decoded, tt.payload) {
+				t.Fatalf("decodePayload() = %+v, want %+v", decoded, tt.payload)
+			}
+		})
+	}
+}
+
+func TestMultisigTransactionPayloadBCS(t *testing.T) {
+	entryFunction := &TransactionPayload{
+		ModuleAddress: cedraFrameworkAddress,
+		ModuleName:    "coin",
+		FunctionName:  "burn",
+	}
+
+	// The payload stored on-chain is the entry function variant followed by the entry function.
+	want := append([]byte{0x00}, cedraFrameworkAddress[:]...)
+	want = append(want, 0x04, 'c', 'o', 'i', 'n', 0x04, 'b', 'u', 'r', 'n', 0x00, 0x00)
+	if got := EncodeMultisigTransactionPayload(entryFunction); !bytes.Equal(got, want) {
+		t.Fatalf("EncodeMultisigTransactionPayload() = %x, want %x", got, want)
+	}
+}
+
+func TestDecodeMultisigPayloadRejectsInvalidInput(t *testing.T) {
+	multisigAddress := [32]byte{31: 0x55}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "invalid option tag", data: append(append([]byte{0x03}, multisigAddress[:]...), 0x02)},
+		{name: "unknown entry function variant", data: append(append([]byte{0x03}, multisigAddress[:]...), 0x01, 0x01)},
+		{name: "missing option", data: append([]byte{0x03}, multisigAddress[:]...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodePayload(NewBCSDecoder(tt.data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
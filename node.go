@@ -2,10 +2,12 @@ package cedra
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -19,6 +21,12 @@ const (
 	clientHeaderValue = "cedra-tx-publisher"
 	// contentTypeAptosSignedTxnBcs is the content type for signed transaction BCS data.
 	contentTypeAptosSignedTxnBcs = "application/x.cedra.signed_transaction+bcs"
+	// contentTypeViewFunctionBcs is the content type for view function requests in BCS format.
+	contentTypeViewFunctionBcs = "application/x.cedra.view_function+bcs"
+	// contentTypeBcs is the content type for responses in BCS format.
+	contentTypeBcs = "application/x-bcs"
+	// contentTypeJSON is the content type for JSON requests and responses.
+	contentTypeJSON = "application/json"
 	// defaultHTTPTimeout is the default timeout for HTTP requests.
 	defaultHTTPTimeout = 30 * time.Second
 )
@@ -31,57 +39,109 @@ type CedraNode struct {
 	nodeURL url.URL
 	// httpClient is the HTTP client used for making requests to the node.
 	httpClient *http.Client
+	// retryPolicy configures the retries of requests that failed with a transient error.
+	retryPolicy RetryPolicy
 }
 
 // NewCedraNode creates a new CedraNode instance for the specified chain.
-// Panics if the chain configuration is invalid or the chain ID doesn't exist.
-func NewCedraNode(chainID ChainID) CedraNode {
-	if CedraChains == nil {
-		panic(errors.New("can't create a new instance of CedraNode: invalid chain config"))
+// The node URL is taken from CedraChains unless it is set with WithNodeURL or WithLocalnet.
+// Returns an error if the chain ID is 0, if no URL is known for the chain or if an option is invalid.
+func NewCedraNode(chainID ChainID, options ...NodeOption) (CedraNode, error) {
+	if chainID == 0 {
+		return CedraNode{}, errors.New("can't create a new instance of CedraNode: chain id should be greater than 0")
 	}
 
+	config := newNodeConfig(options)
 	chain, ok := CedraChains[chainID]
-	if !ok {
-		panic(errors.New("can't create a new instance of CedraNode: requested chain doesn't exist"))
+	if config.nodeURL != "" {
+		chain = Chain{CedraNodeUrl: config.nodeURL, ChainID: chainID}
+	} else if !ok {
+		return CedraNode{}, errors.Errorf("can't create a new instance of CedraNode: no node url for chain %d", chainID)
 	}
-	nodeURL, err := url.Parse(chain.CedraNodeUrl)
+	nodeURL, err := parseNodeURL(chain.CedraNodeUrl)
 	if err != nil {
-		panic(errors.Wrapf(err, "can't create a new instance of CedraNode"))
+		return CedraNode{}, errors.Wrap(err, "can't create a new instance of CedraNode")
+	}
+	httpClient, err := config.newHTTPClient()
+	if err != nil {
+		return CedraNode{}, errors.Wrap(err, "can't create a new instance of CedraNode")
 	}
 
 	return CedraNode{
-		chain:   chain,
-		nodeURL: *nodeURL,
-		httpClient: &http.Client{
-			Timeout: defaultHTTPTimeout,
-		},
-	}
+		chain:       chain,
+		nodeURL:     *nodeURL,
+		httpClient:  httpClient,
+		retryPolicy: config.retryPolicy,
+	}, nil
 }
 
 // SubmitTransaction submits a signed transaction to the Cedra node.
+// Failed submissions are retried with the same signed bytes: the transaction can't be executed twice,
+// and when a retry is rejected the transaction is looked up by its hash in case an earlier attempt was accepted.
 // Returns the transaction hash if successful, or an error if submission fails.
-func (n CedraNode) SubmitTransaction(tx []byte) (string, error) {
-	requestBody := bytes.NewReader(tx)
+func (n CedraNode) SubmitTransaction(ctx context.Context, tx []byte) (string, error) {
 	requestURL := n.nodeURL.JoinPath("transactions")
 	headers := map[string]string{
 		"content-type": contentTypeAptosSignedTxnBcs,
 	}
 
-	hash, err := makeRequest[TransactionDTO](http.MethodPost, requestURL, requestBody, headers, n.httpClient)
+	hash := signedTransactionHash(tx)
+	attempt := 0
+	submitted, err := retryRequest(ctx, n.retryPolicy, func() (TransactionDTO, error) {
+		attempt++
+		submitted, err := makeRequest[TransactionDTO](ctx, http.MethodPost, requestURL, bytes.NewReader(tx), headers, n.httpClient)
+		var apiErr *APIError
+		if err != nil && attempt > 1 && errors.As(err, &apiErr) && !isRetryableStatus(apiErr.StatusCode) {
+			// An earlier attempt may have been accepted before its response was lost.
+			if _, lookupErr := n.getTransactionByHash(ctx, hash, RetryPolicy{}); lookupErr == nil {
+				return TransactionDTO{Hash: hash}, nil
+			}
+		}
+
+		return submitted, err
+	})
 	if err != nil {
 		return "", errors.Wrap(err, "can't execute requested transaction")
 	}
 
-	return hash.Hash, nil
+	return submitted.Hash, nil
+}
+
+// SimulateTransaction simulates a transaction signed with an invalid signature on the Cedra node.
+// If estimateMaxGasAmount is true the node ignores the transaction's max gas amount and simulates it
+// with the maximum amount the sender can afford.
+// Returns the simulated transaction result, or an error if the request fails.
+func (n CedraNode) SimulateTransaction(ctx context.Context, tx []byte, estimateMaxGasAmount bool) (TransactionDTO, error) {
+	requestURL := n.nodeURL.JoinPath("transactions/simulate")
+	if estimateMaxGasAmount {
+		requestURL.RawQuery = url.Values{"estimate_max_gas_amount": {"true"}}.Encode()
+	}
+	headers := map[string]string{
+		"content-type": contentTypeAptosSignedTxnBcs,
+	}
+
+	results, err := retryRequest(ctx, n.retryPolicy, func() ([]TransactionDTO, error) {
+		return makeRequest[[]TransactionDTO](ctx, http.MethodPost, requestURL, bytes.NewReader(tx), headers, n.httpClient)
+	})
+	if err != nil {
+		return TransactionDTO{}, errors.Wrap(err, "can't simulate requested transaction")
+	}
+	if len(results) == 0 {
+		return TransactionDTO{}, errors.New("can't simulate requested transaction: empty simulation result")
+	}
+
+	return results[0], nil
 }
 
 // GetEstimateGasPrice retrieves the current gas price estimates from the Cedra node.
 // Returns gas price estimates for different priority levels.
-func (n CedraNode) GetEstimateGasPrice() (EstimateGasPriceDTO, error) {
+func (n CedraNode) GetEstimateGasPrice(ctx context.Context) (EstimateGasPriceDTO, error) {
 	var body io.Reader
 	var headers map[string]string
 	requestURL := n.nodeURL.JoinPath("estimate_gas_price")
-	estimateGasPrice, err := makeRequest[EstimateGasPriceDTO](http.MethodGet, requestURL, body, headers, n.httpClient)
+	estimateGasPrice, err := retryRequest(ctx, n.retryPolicy, func() (EstimateGasPriceDTO, error) {
+		return makeRequest[EstimateGasPriceDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
 	if err != nil {
 		return estimateGasPrice, errors.Wrap(err, "can't estimate gas price")
 	}
@@ -91,11 +151,13 @@ func (n CedraNode) GetEstimateGasPrice() (EstimateGasPriceDTO, error) {
 
 // GetSequenceNumber retrieves the current sequence number for the specified account address.
 // Returns the sequence number as a uint64, or an error if the request fails.
-func (n CedraNode) GetSequenceNumber(address string) (uint64, error) {
+func (n CedraNode) GetSequenceNumber(ctx context.Context, address string) (uint64, error) {
 	var body io.Reader
 	var headers map[string]string
 	requestURL := n.nodeURL.JoinPath("accounts", address)
-	accountInfo, err := makeRequest[AccountDTO](http.MethodGet, requestURL, body, headers, n.httpClient)
+	accountInfo, err := retryRequest(ctx, n.retryPolicy, func() (AccountDTO, error) {
+		return makeRequest[AccountDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
 	if err != nil {
 		return 0, errors.Wrap(err, "can't get account info")
 	}
@@ -106,12 +168,14 @@ func (n CedraNode) GetSequenceNumber(address string) (uint64, error) {
 // WaitTxByHash waits for a transaction to be processed and returns its status from the Cedra node.
 // This method queries the node's wait endpoint for the specified transaction hash.
 // Returns the transaction DTO containing the transaction details and status, or an error if the request fails.
-func (n CedraNode) WaitTxByHash(txHash string) (TransactionDTO, error) {
+func (n CedraNode) WaitTxByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
 	var body io.Reader
 	var headers map[string]string
 	requestURL := n.nodeURL.JoinPath("transactions/wait_by_hash", txHash)
 
-	tx, err := makeRequest[TransactionDTO](http.MethodGet, requestURL, body, headers, n.httpClient)
+	tx, err := retryRequest(ctx, n.retryPolicy, func() (TransactionDTO, error) {
+		return makeRequest[TransactionDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
 	if err != nil {
 		return TransactionDTO{}, errors.Wrap(err, "can't wait for requested transaction")
 	}
@@ -119,14 +183,141 @@ func (n CedraNode) WaitTxByHash(txHash string) (TransactionDTO, error) {
 	return tx, nil
 }
 
+// GetTransactionByHash retrieves a pending or committed transaction by its hash from the Cedra node.
+// Returns the transaction DTO containing the transaction details and status, or an error if the request fails.
+func (n CedraNode) GetTransactionByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
+	return n.getTransactionByHash(ctx, txHash, n.retryPolicy)
+}
+
+// getTransactionByHash retrieves a transaction by its hash, retrying the request according to the policy.
+func (n CedraNode) getTransactionByHash(ctx context.Context, txHash string, policy RetryPolicy) (TransactionDTO, error) {
+	var body io.Reader
+	var headers map[string]string
+	requestURL := n.nodeURL.JoinPath("transactions/by_hash", txHash)
+
+	tx, err := retryRequest(ctx, policy, func() (TransactionDTO, error) {
+		return makeRequest[TransactionDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
+	if err != nil {
+		return TransactionDTO{}, errors.Wrap(err, "can't get requested transaction")
+	}
+
+	return tx, nil
+}
+
+// GetAccountModule retrieves the Move module published under the account address with the module name.
+// Returns the module bytecode and ABI, or an error if the request fails.
+func (n CedraNode) GetAccountModule(ctx context.Context, address string, moduleName string) (MoveModuleDTO, error) {
+	var body io.Reader
+	var headers map[string]string
+	requestURL := n.nodeURL.JoinPath("accounts", address, "module", moduleName)
+
+	module, err := retryRequest(ctx, n.retryPolicy, func() (MoveModuleDTO, error) {
+		return makeRequest[MoveModuleDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
+	if err != nil {
+		return MoveModuleDTO{}, errors.Wrap(err, "can't get account module")
+	}
+
+	return module, nil
+}
+
+// GetLedgerInfo retrieves the chain ID, ledger version and other information about the ledger of the Cedra node.
+// Returns the ledger information, or an error if the request fails.
+func (n CedraNode) GetLedgerInfo(ctx context.Context) (LedgerInfoDTO, error) {
+	return n.getLedgerInfo(ctx, n.retryPolicy)
+}
+
+// getLedgerInfo retrieves the ledger information, retrying the request according to the policy.
+func (n CedraNode) getLedgerInfo(ctx context.Context, policy RetryPolicy) (LedgerInfoDTO, error) {
+	var body io.Reader
+	var headers map[string]string
+	requestURL := n.nodeURL.JoinPath()
+	requestURL.Path = strings.TrimSuffix(requestURL.Path, "/")
+
+	ledgerInfo, err := retryRequest(ctx, policy, func() (LedgerInfoDTO, error) {
+		return makeRequest[LedgerInfoDTO](ctx, http.MethodGet, requestURL, body, headers, n.httpClient)
+	})
+	if err != nil {
+		return LedgerInfoDTO{}, errors.Wrap(err, "can't get ledger info")
+	}
+
+	return ledgerInfo, nil
+}
+
+// ViewBCS calls a view function on the Cedra node with a BCS-encoded view function request.
+// Returns the BCS-encoded vector of BCS-encoded return values, or an error if the request fails.
+func (n CedraNode) ViewBCS(ctx context.Context, request []byte) ([]byte, error) {
+	requestURL := n.nodeURL.JoinPath("view")
+	headers := map[string]string{
+		"content-type": contentTypeViewFunctionBcs,
+		"accept":       contentTypeBcs,
+	}
+
+	response, err := retryRequest(ctx, n.retryPolicy, func() ([]byte, error) {
+		return makeRawRequest(ctx, http.MethodPost, requestURL, bytes.NewReader(request), headers, n.httpClient)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "can't call view function")
+	}
+
+	return response, nil
+}
+
+// ViewJSON calls a view function on the Cedra node with a JSON view function request.
+// Returns the JSON return values, or an error if the request fails.
+func (n CedraNode) ViewJSON(ctx context.Context, request []byte) ([]json.RawMessage, error) {
+	requestURL := n.nodeURL.JoinPath("view")
+	headers := map[string]string{
+		"content-type": contentTypeJSON,
+		"accept":       contentTypeJSON,
+	}
+
+	values, err := retryRequest(ctx, n.retryPolicy, func() ([]json.RawMessage, error) {
+		return makeRequest[[]json.RawMessage](ctx, http.MethodPost, requestURL, bytes.NewReader(request), headers, n.httpClient)
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "can't call view function")
+	}
+
+	return values, nil
+}
+
+// URL returns the base URL of the node API.
+func (n CedraNode) URL() string {
+	return n.nodeURL.String()
+}
+
+// ChainID returns the chain identifier the node was created for.
+func (n CedraNode) ChainID() ChainID {
+	return n.chain.ChainID
+}
+
 // makeRequest performs an HTTP request to the Cedra node and unmarshals the JSON response.
+// The request is cancelled when the context is done.
 // It is a generic function that can handle different response types.
-// Returns the unmarshaled response or an error if the request fails.
-func makeRequest[T any](method string, requestURL *url.URL, body io.Reader, headers map[string]string, client *http.Client) (T, error) {
+// Returns the unmarshaled response or an error if the request fails; non-successful responses are returned as *APIError.
+func makeRequest[T any](ctx context.Context, method string, requestURL *url.URL, body io.Reader, headers map[string]string, client *http.Client) (T, error) {
 	var response T
-	req, err := http.NewRequest(method, requestURL.String(), body)
+	bodyBytes, err := makeRawRequest(ctx, method, requestURL, body, headers, client)
+	if err != nil {
+		return response, err
+	}
+
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return response, errors.Wrap(err, "can't unmarshal response to object")
+	}
+
+	return response, nil
+}
+
+// makeRawRequest performs an HTTP request to the Cedra node and returns the response body.
+// The request is cancelled when the context is done.
+// Returns an error if the request fails; non-successful responses are returned as *APIError.
+func makeRawRequest(ctx context.Context, method string, requestURL *url.URL, body io.Reader, headers map[string]string, client *http.Client) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, method, requestURL.String(), body)
 	if err != nil {
-		return response, errors.Wrap(err, "can't create a new request")
+		return nil, errors.Wrap(err, "can't create a new request")
 	}
 
 	req.Header.Set(clientHeader, clientHeaderValue)
@@ -136,22 +327,18 @@ func makeRequest[T any](method string, requestURL *url.URL, body io.Reader, head
 
 	resp, err := client.Do(req)
 	if err != nil {
-		return response, errors.Wrap(err, "can't execute request")
+		return nil, errors.Wrap(err, "can't execute request")
 	}
 	defer resp.Body.Close()
 
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return response, errors.Wrap(err, "can't read request response body")
+		return nil, errors.Wrap(err, "can't read request response body")
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
-		return response, errors.New(resp.Status + ": " + string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes)
 	}
 
-	if err := json.Unmarshal(bodyBytes, &response); err != nil {
-		return response, errors.Wrap(err, "can't unmarshal response to object")
-	}
-
-	return response, nil
+	return bodyBytes, nil
 }
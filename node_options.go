@@ -0,0 +1,159 @@
+package cedra
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// userAgentHeader is the HTTP header name for the user agent.
+	userAgentHeader = "User-Agent"
+	// authorizationHeader is the HTTP header name carrying the API key.
+	authorizationHeader = "Authorization"
+)
+
+// NodeOption configures a CedraNode created with NewCedraNode.
+type NodeOption func(*nodeConfig)
+
+// nodeConfig holds the settings collected from the node options.
+type nodeConfig struct {
+	// nodeURL is the base URL of the node API, replacing the URL of the chain configuration.
+	nodeURL string
+	// headers are sent with every request to the node.
+	headers map[string]string
+	// httpClient is the HTTP client used for requests to the node.
+	httpClient *http.Client
+	// transport replaces the transport of the HTTP client.
+	transport http.RoundTripper
+	// retryPolicy configures the retries of failed requests.
+	retryPolicy RetryPolicy
+}
+
+// WithNodeURL sets the base URL of the node API (e.g. "https://node.example.com/v1/"),
+// for own full nodes or chains missing from CedraChains.
+func WithNodeURL(nodeURL string) NodeOption {
+	return func(c *nodeConfig) {
+		c.nodeURL = nodeURL
+	}
+}
+
+// WithLocalnet sets the base URL of the node API to LocalnetNodeURL, the default endpoint of a local network.
+func WithLocalnet() NodeOption {
+	return WithNodeURL(LocalnetNodeURL)
+}
+
+// WithAPIKey sends the API key as a bearer token in the Authorization header of every request.
+func WithAPIKey(apiKey string) NodeOption {
+	return WithHeader(authorizationHeader, "Bearer "+apiKey)
+}
+
+// WithHeader sends the header with every request, e.g. an API key expected in a custom header.
+func WithHeader(name string, value string) NodeOption {
+	return func(c *nodeConfig) {
+		c.headers[name] = value
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) NodeOption {
+	return WithHeader(userAgentHeader, userAgent)
+}
+
+// WithHTTPClient sets the HTTP client used for requests to the node, e.g. to change its timeout.
+// The client is copied, it can be shared with other users.
+func WithHTTPClient(client *http.Client) NodeOption {
+	return func(c *nodeConfig) {
+		c.httpClient = client
+	}
+}
+
+// WithRoundTripper sets the transport of the HTTP client used for requests to the node,
+// e.g. for instrumentation or a custom TLS configuration.
+func WithRoundTripper(transport http.RoundTripper) NodeOption {
+	return func(c *nodeConfig) {
+		c.transport = transport
+	}
+}
+
+// WithRetryPolicy sets the policy retrying requests that failed with a transient error,
+// replacing DefaultRetryPolicy. Use RetryPolicy{} to disable retries.
+func WithRetryPolicy(policy RetryPolicy) NodeOption {
+	return func(c *nodeConfig) {
+		c.retryPolicy = policy
+	}
+}
+
+// newNodeConfig applies the options over the default settings.
+func newNodeConfig(options []NodeOption) nodeConfig {
+	config := nodeConfig{
+		headers:     make(map[string]string),
+		retryPolicy: DefaultRetryPolicy(),
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// newHTTPClient creates the HTTP client for requests to the node from the configuration.
+func (c nodeConfig) newHTTPClient() (*http.Client, error) {
+	client := &http.Client{Timeout: defaultHTTPTimeout}
+	if c.httpClient != nil {
+		copied := *c.httpClient
+		client = &copied
+	}
+	if c.transport != nil {
+		client.Transport = c.transport
+	}
+	if len(c.headers) == 0 {
+		return client, nil
+	}
+
+	for name := range c.headers {
+		if name == "" {
+			return nil, errors.New("empty header name")
+		}
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.Transport = &headerTransport{base: transport, headers: c.headers}
+
+	return client, nil
+}
+
+// headerTransport is an http.RoundTripper adding headers to every request.
+type headerTransport struct {
+	// base is the transport performing the requests.
+	base http.RoundTripper
+	// headers are set on every request.
+	headers map[string]string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it receives.
+	req = req.Clone(req.Context())
+	for name, value := range t.headers {
+		req.Header.Set(name, value)
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// parseNodeURL parses the base URL of the node API.
+func parseNodeURL(rawURL string) (*url.URL, error) {
+	nodeURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid node url")
+	}
+	if nodeURL.Scheme != "http" && nodeURL.Scheme != "https" || nodeURL.Host == "" {
+		return nil, errors.Errorf("invalid node url %q: expected an http or https url", rawURL)
+	}
+
+	return nodeURL, nil
+}
@@ -0,0 +1,393 @@
+package cedra
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultMaxLedgerLag is the default number of ledger versions a node can lag behind the most
+	// up-to-date node of the pool before reads avoid it.
+	defaultMaxLedgerLag = 100
+	// healthCheckTimeout is the time allowed to a node to answer a health check.
+	healthCheckTimeout = 5 * time.Second
+	// broadcastTimeout is the time allowed to the nodes to answer a broadcast submission.
+	// Submissions still running when a node accepted the transaction go on in the background until then.
+	broadcastTimeout = time.Minute
+)
+
+// SubmitMode selects how a NodePool submits transactions.
+type SubmitMode int
+
+const (
+	// SubmitToBest submits transactions to the healthiest node, failing over to the next nodes
+	// when it is unavailable. The same signed bytes are resubmitted, so the transaction can't be executed twice.
+	SubmitToBest SubmitMode = iota
+	// SubmitBroadcast submits transactions to every node concurrently and succeeds if any node accepts them.
+	SubmitBroadcast
+)
+
+// NodeHealth describes the state of a node of a NodePool as of its last health check.
+type NodeHealth struct {
+	// URL is the base URL of the node API.
+	URL string
+	// Healthy reports whether the node answered its last health check, on the expected chain,
+	// and hasn't failed a request since.
+	Healthy bool
+	// LedgerVersion is the latest ledger version of the node at its last health check.
+	LedgerVersion uint64
+	// Latency is the duration of the last health check request.
+	Latency time.Duration
+	// CheckedAt is the time of the last health check, zero if the node wasn't checked yet.
+	CheckedAt time.Time
+	// Err is the error of the last health check or failed request, if the node isn't healthy.
+	Err error
+}
+
+// NodePool holds several nodes of the same chain. Reads are routed to the healthiest, least-lagging node
+// and fail over to the next ones when a node is unavailable; transactions are submitted according to the SubmitMode.
+// Node health is updated by CheckHealth, e.g. periodically with StartHealthChecks. A NodePool is safe for concurrent use.
+type NodePool struct {
+	// nodes are the nodes of the pool, in the order they were provided.
+	nodes []CedraNode
+	// chainID identifies the blockchain network of every node.
+	chainID ChainID
+	// submitMode selects how transactions are submitted.
+	submitMode SubmitMode
+	// maxLedgerLag is the number of ledger versions a node can lag behind before reads avoid it.
+	maxLedgerLag uint64
+	// mu protects health.
+	mu sync.RWMutex
+	// health is the state of each node, in the order of nodes.
+	health []NodeHealth
+}
+
+// PoolOption configures a NodePool created with NewNodePool.
+type PoolOption func(*NodePool)
+
+// WithSubmitMode sets how the pool submits transactions, SubmitToBest by default.
+func WithSubmitMode(mode SubmitMode) PoolOption {
+	return func(p *NodePool) {
+		p.submitMode = mode
+	}
+}
+
+// WithMaxLedgerLag sets the number of ledger versions a node can lag behind the most up-to-date node
+// before reads are routed to it only as a fallback.
+func WithMaxLedgerLag(versions uint64) PoolOption {
+	return func(p *NodePool) {
+		p.maxLedgerLag = versions
+	}
+}
+
+// NewNodePool creates a pool of the nodes, which must all be created for the same chain.
+// Until the first health check, nodes are used in the order they are provided.
+// Returns an error if no node is provided or if the nodes are on different chains.
+func NewNodePool(nodes []CedraNode, options ...PoolOption) (*NodePool, error) {
+	if len(nodes) == 0 {
+		return nil, errors.New("can't create node pool: no nodes")
+	}
+	chainID := nodes[0].ChainID()
+	health := make([]NodeHealth, 0, len(nodes))
+	for _, node := range nodes {
+		if node.ChainID() != chainID {
+			return nil, errors.Errorf("can't create node pool: node %s is on chain %d, expected %d", node.URL(), node.ChainID(), chainID)
+		}
+		health = append(health, NodeHealth{URL: node.URL(), Healthy: true})
+	}
+
+	pool := &NodePool{
+		nodes:        slices.Clone(nodes),
+		chainID:      chainID,
+		submitMode:   SubmitToBest,
+		maxLedgerLag: defaultMaxLedgerLag,
+		health:       health,
+	}
+	for _, option := range options {
+		option(pool)
+	}
+
+	return pool, nil
+}
+
+// ChainID returns the chain identifier of the nodes.
+func (p *NodePool) ChainID() ChainID {
+	return p.chainID
+}
+
+// Health returns the state of each node, in the order they were provided.
+func (p *NodePool) Health() []NodeHealth {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return slices.Clone(p.health)
+}
+
+// CheckHealth fetches the ledger info of every node concurrently and updates their health:
+// a node is healthy if it answers on the expected chain within a short timeout, without retries.
+// Returns the updated state of each node.
+func (p *NodePool) CheckHealth(ctx context.Context) []NodeHealth {
+	health := make([]NodeHealth, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, node := range p.nodes {
+		wg.Go(func() {
+			health[i] = p.checkNode(ctx, node)
+		})
+	}
+	wg.Wait()
+
+	p.mu.Lock()
+	p.health = health
+	p.mu.Unlock()
+
+	return slices.Clone(health)
+}
+
+// StartHealthChecks checks the health of the nodes immediately and then at every interval
+// in a background goroutine, until the context is done.
+func (p *NodePool) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			p.CheckHealth(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// checkNode fetches the ledger info of the node in a single attempt and returns its health.
+func (p *NodePool) checkNode(ctx context.Context, node CedraNode) NodeHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	ledgerInfo, err := node.getLedgerInfo(ctx, RetryPolicy{})
+	health := NodeHealth{
+		URL:       node.URL(),
+		Latency:   time.Since(start),
+		CheckedAt: time.Now(),
+	}
+	if err == nil && ChainID(ledgerInfo.ChainID) != p.chainID {
+		err = errors.Errorf("node is on chain %d, expected %d", ledgerInfo.ChainID, p.chainID)
+	}
+	if err != nil {
+		health.Err = err
+
+		return health
+	}
+	health.Healthy = true
+	health.LedgerVersion = ledgerInfo.LedgerVersion
+
+	return health
+}
+
+// markFailed marks the node as unhealthy after a failed request, until its next health check.
+func (p *NodePool) markFailed(index int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.health[index].Healthy = false
+	p.health[index].Err = err
+}
+
+// ordered returns the indexes of the nodes from the most to the least preferred: healthy nodes within
+// the max ledger lag by latency, then lagging nodes by ledger version, then unhealthy nodes.
+func (p *NodePool) ordered() []int {
+	health := p.Health()
+
+	var highestVersion uint64
+	for _, h := range health {
+		if h.Healthy {
+			highestVersion = max(highestVersion, h.LedgerVersion)
+		}
+	}
+	rank := func(h NodeHealth) int {
+		switch {
+		case !h.Healthy:
+			return 2
+		case highestVersion-h.LedgerVersion > p.maxLedgerLag:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	indexes := make([]int, len(health))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	slices.SortStableFunc(indexes, func(a, b int) int {
+		rankA, rankB := rank(health[a]), rank(health[b])
+		if rankA != rankB {
+			return cmp.Compare(rankA, rankB)
+		}
+		switch rankA {
+		case 0:
+			return cmp.Compare(health[a].Latency, health[b].Latency)
+		case 1:
+			return cmp.Compare(health[b].LedgerVersion, health[a].LedgerVersion)
+		default:
+			return 0
+		}
+	})
+
+	return indexes
+}
+
+// isNodeFailure reports whether the request failed because of the node rather than the request itself,
+// i.e. a connection error, a server error or rate limiting, so that it can be sent to another node.
+func isNodeFailure(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	return isConnectionError(err)
+}
+
+// poolRequest sends the request to the nodes of the pool from the most preferred one,
+// failing over to the next node while the request fails because of the node.
+func poolRequest[T any](ctx context.Context, p *NodePool, request func(CedraNode) (T, error)) (T, error) {
+	var response T
+	var err error
+	for _, index := range p.ordered() {
+		response, err = request(p.nodes[index])
+		if err == nil || !isNodeFailure(err) || ctx.Err() != nil {
+			return response, err
+		}
+		p.markFailed(index, err)
+	}
+
+	return response, err
+}
+
+// SubmitTransaction submits a signed transaction according to the submit mode of the pool.
+// Returns the transaction hash if successful, or an error if submission fails.
+func (p *NodePool) SubmitTransaction(ctx context.Context, tx []byte) (string, error) {
+	if p.submitMode == SubmitBroadcast {
+		return p.broadcastTransaction(ctx, tx)
+	}
+
+	return poolRequest(ctx, p, func(node CedraNode) (string, error) {
+		return node.SubmitTransaction(ctx, tx)
+	})
+}
+
+// broadcastTransaction submits a signed transaction to every node concurrently and returns as soon as a node accepts it.
+// The other submissions go on in the background on a context detached from ctx, bounded by broadcastTimeout.
+// Returns the transaction hash if any node accepted it, or the error of the most preferred node.
+func (p *NodePool) broadcastTransaction(ctx context.Context, tx []byte) (string, error) {
+	type result struct {
+		index int
+		hash  string
+		err   error
+	}
+
+	order := p.ordered()
+	submitCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), broadcastTimeout)
+	results := make(chan result, len(p.nodes))
+	var wg sync.WaitGroup
+	for i, node := range p.nodes {
+		wg.Go(func() {
+			hash, err := node.SubmitTransaction(submitCtx, tx)
+			if err != nil && isNodeFailure(err) {
+				p.markFailed(i, err)
+			}
+			results <- result{index: i, hash: hash, err: err}
+		})
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+	}()
+
+	errs := make([]error, len(p.nodes))
+	for range p.nodes {
+		select {
+		case <-ctx.Done():
+			return "", errors.Wrap(ctx.Err(), "can't submit transaction")
+		case res := <-results:
+			if res.err == nil {
+				return res.hash, nil
+			}
+			errs[res.index] = res.err
+		}
+	}
+
+	return "", errors.Wrap(errs[order[0]], "transaction rejected by every node")
+}
+
+// SimulateTransaction simulates a transaction on the most preferred node.
+func (p *NodePool) SimulateTransaction(ctx context.Context, tx []byte, estimateMaxGasAmount bool) (TransactionDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (TransactionDTO, error) {
+		return node.SimulateTransaction(ctx, tx, estimateMaxGasAmount)
+	})
+}
+
+// GetEstimateGasPrice retrieves the gas price estimates from the most preferred node.
+func (p *NodePool) GetEstimateGasPrice(ctx context.Context) (EstimateGasPriceDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (EstimateGasPriceDTO, error) {
+		return node.GetEstimateGasPrice(ctx)
+	})
+}
+
+// GetSequenceNumber retrieves the sequence number of the account from the most preferred node.
+func (p *NodePool) GetSequenceNumber(ctx context.Context, address string) (uint64, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (uint64, error) {
+		return node.GetSequenceNumber(ctx, address)
+	})
+}
+
+// WaitTxByHash waits for a transaction to be processed on the most preferred node.
+func (p *NodePool) WaitTxByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (TransactionDTO, error) {
+		return node.WaitTxByHash(ctx, txHash)
+	})
+}
+
+// GetTransactionByHash retrieves a pending or committed transaction from the most preferred node.
+func (p *NodePool) GetTransactionByHash(ctx context.Context, txHash string) (TransactionDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (TransactionDTO, error) {
+		return node.GetTransactionByHash(ctx, txHash)
+	})
+}
+
+// GetAccountModule retrieves the Move module of the account from the most preferred node.
+func (p *NodePool) GetAccountModule(ctx context.Context, address string, moduleName string) (MoveModuleDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (MoveModuleDTO, error) {
+		return node.GetAccountModule(ctx, address, moduleName)
+	})
+}
+
+// GetLedgerInfo retrieves the ledger information from the most preferred node.
+func (p *NodePool) GetLedgerInfo(ctx context.Context) (LedgerInfoDTO, error) {
+	return poolRequest(ctx, p, func(node CedraNode) (LedgerInfoDTO, error) {
+		return node.GetLedgerInfo(ctx)
+	})
+}
+
+// ViewBCS calls a view function with a BCS-encoded request on the most preferred node.
+func (p *NodePool) ViewBCS(ctx context.Context, request []byte) ([]byte, error) {
+	return poolRequest(ctx, p, func(node CedraNode) ([]byte, error) {
+		return node.ViewBCS(ctx, request)
+	})
+}
+
+// ViewJSON calls a view function with a JSON request on the most preferred node.
+func (p *NodePool) ViewJSON(ctx context.Context, request []byte) ([]json.RawMessage, error) {
+	return poolRequest(ctx, p, func(node CedraNode) ([]json.RawMessage, error) {
+		return node.ViewJSON(ctx, request)
+	})
+}
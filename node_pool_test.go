@@ -0,0 +1,183 @@
+package cedra
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// newTestNodePool returns a pool of nodes served by the handlers, retrying requests up to three times.
+func newTestNodePool(t *testing.T, mode SubmitMode, handlers ...http.HandlerFunc) *NodePool {
+	t.Helper()
+	nodes := make([]CedraNode, 0, len(handlers))
+	for _, handler := range handlers {
+		server := httptest.NewServer(handler)
+		t.Cleanup(server.Close)
+		node, err := NewCedraNode(TestnetChainID,
+			WithNodeURL(server.URL+"/v1/"),
+			WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, node)
+	}
+	pool, err := NewNodePool(nodes, WithSubmitMode(mode))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pool
+}
+
+func TestIsNodeFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "500", err: &APIError{StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "wrapped 503", err: errors.Wrap(&APIError{StatusCode: http.StatusServiceUnavailable}, "request"), want: true},
+		{name: "429", err: &APIError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "400", err: &APIError{StatusCode: http.StatusBadRequest}},
+		{name: "404", err: &APIError{StatusCode: http.StatusNotFound}},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, want: true},
+		{name: "timeout", err: timeoutError{}, want: true},
+		{name: "invalid json", err: errors.Wrap(&json.SyntaxError{}, "can't decode response")},
+		{name: "cancelled", err: context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNodeFailure(tt.err); got != tt.want {
+				t.Fatalf("isNodeFailure(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNodePoolCheckHealthMakesSingleAttempt(t *testing.T) {
+	var requests atomic.Int32
+	pool := newTestNodePool(t, SubmitToBest,
+		func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]any{"chain_id": uint8(TestnetChainID), "ledger_version": "42"})
+		},
+		func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	)
+
+	health := pool.CheckHealth(context.Background())
+	if !health[0].Healthy || health[0].LedgerVersion != 42 {
+		t.Fatalf("first node health = %+v, want healthy at version 42", health[0])
+	}
+	if health[1].Healthy || health[1].Err == nil {
+		t.Fatalf("second node health = %+v, want unhealthy", health[1])
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("%d health check requests, want 1", requests.Load())
+	}
+}
+
+func TestNodePoolOrdered(t *testing.T) {
+	handlers := make([]http.HandlerFunc, 5)
+	for i := range handlers {
+		handlers[i] = http.NotFound
+	}
+	pool := newTestNodePool(t, SubmitToBest, handlers...)
+	pool.health = []NodeHealth{
+		// Unhealthy, even though it is the fastest and up to date.
+		{Healthy: false, LedgerVersion: 1000, Latency: time.Millisecond},
+		// Lagging far behind the highest healthy version.
+		{Healthy: true, LedgerVersion: 100, Latency: time.Millisecond},
+		// Up to date but slow.
+		{Healthy: true, LedgerVersion: 1000, Latency: 30 * time.Millisecond},
+		// Within the maximum lag and fast.
+		{Healthy: true, LedgerVersion: 1000 - defaultMaxLedgerLag, Latency: 10 * time.Millisecond},
+		// Lagging, but less than the second node.
+		{Healthy: true, LedgerVersion: 500, Latency: time.Millisecond},
+	}
+
+	// Up to date nodes by latency, then lagging nodes by ledger version, then unhealthy nodes.
+	want := []int{3, 2, 4, 1, 0}
+	if got := pool.ordered(); !slices.Equal(got, want) {
+		t.Fatalf("ordered() = %v, want %v", got, want)
+	}
+
+	// Without health checks every node is healthy at version 0 and keeps its position.
+	for i := range pool.health {
+		pool.health[i] = NodeHealth{Healthy: true}
+	}
+	if got := pool.ordered(); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Fatalf("ordered() before health checks = %v, want [0 1 2 3 4]", got)
+	}
+}
+
+func TestNodePoolBroadcastReturnsOnFirstAcceptance(t *testing.T) {
+	release := make(chan struct{})
+	var slowDone atomic.Bool
+	pool := newTestNodePool(t, SubmitBroadcast,
+		func(w http.ResponseWriter, r *http.Request) {
+			// The slow node answers only once the broadcast returned.
+			<-release
+			slowDone.Store(true)
+			_ = json.NewEncoder(w).Encode(map[string]string{"hash": "0x01"})
+		},
+		func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]string{"hash": "0x01"})
+		},
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	hash, err := pool.SubmitTransaction(ctx, []byte{1, 2, 3})
+	// Cancelling the caller context must not cancel the remaining submissions.
+	cancel()
+	close(release)
+	if err != nil {
+		t.Fatalf("SubmitTransaction() error = %v", err)
+	}
+	if hash != "0x01" {
+		t.Fatalf("SubmitTransaction() = %s, want 0x01", hash)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for !slowDone.Load() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !slowDone.Load() {
+		t.Fatal("the submission to the slow node didn't complete")
+	}
+	if health := pool.Health(); !health[0].Healthy {
+		t.Fatalf("slow node health = %+v, want healthy", health[0])
+	}
+}
+
+func TestNodePoolBroadcastRejectedByEveryNode(t *testing.T) {
+	reject := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid signature", "error_code": "invalid_input"})
+	}
+	pool := newTestNodePool(t, SubmitBroadcast, reject, reject)
+
+	_, err := pool.SubmitTransaction(context.Background(), []byte{1, 2, 3})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("SubmitTransaction() error = %v, want a 400 APIError", err)
+	}
+	// A rejected transaction doesn't make the nodes unhealthy.
+	for _, health := range pool.Health() {
+		if !health.Healthy {
+			t.Fatalf("node health = %+v, want healthy", health)
+		}
+	}
+}
@@ -1,15 +1,24 @@
 package cedra
 
-import "github.com/spf13/cast"
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
 
 const (
 	// transactionPayloadVariant is the variant identifier for transaction payloads.
 	transactionPayloadVariant = 2
-	// txTypedArgsLen is the length of typed arguments (currently always 0).
-	txTypedArgsLen = 0
 )
 
-// TransactionPayload represents the payload of a Cedra transaction.
+// Payload is implemented by the transaction payload variants accepted by Transaction:
+// *TransactionPayload (entry function call), *ScriptPayload (compiled Move script)
+// and *MultisigPayload (execution of a multisig account transaction).
+type Payload interface {
+	// ToBCSBytes encodes the payload, including its variant, into BCS format.
+	ToBCSBytes() []byte
+}
+
+// TransactionPayload represents the entry function payload of a Cedra transaction.
 // It specifies which module function to call and with what arguments.
 type TransactionPayload struct {
 	// ModuleAddress is the 32-byte address of the module to call.
@@ -18,6 +27,8 @@ type TransactionPayload struct {
 	ModuleName string
 	// FunctionName is the name of the function to call.
 	FunctionName string
+	// TypeArguments are the type arguments of a generic function, e.g. the coin type of 0x1::coin::transfer<CoinType>.
+	TypeArguments []TypeTag
 	// Arguments is a slice of byte arrays representing the function arguments.
 	Arguments [][]byte
 }
@@ -28,15 +39,112 @@ func (p *TransactionPayload) ToBCSBytes() []byte {
 	bcs := NewBCSEncoder()
 	defer bcs.buf.Reset()
 	bcs.EncodeEnum(transactionPayloadVariant)
+	p.encodeEntryFunction(bcs)
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes an entry function payload encoded with ToBCSBytes.
+func (p *TransactionPayload) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(transactionPayloadVariant, "entry function payload"); err != nil {
+		return err
+	}
+	payload, err := decodeTransactionPayload(bcs)
+	if err != nil {
+		return err
+	}
+	*p = payload
+
+	return nil
+}
+
+// encodeEntryFunction writes the entry function call without the payload variant.
+// The same encoding is nested inside multisig payloads.
+func (p *TransactionPayload) encodeEntryFunction(bcs *BCSEncoder) {
 	bcs.WriteRawBytes(p.ModuleAddress[:])
 	bcs.EncodeString(p.ModuleName)
 	bcs.EncodeString(p.FunctionName)
-	bcs.EncodeEnum(txTypedArgsLen)
+	bcs.EncodeEnum(cast.ToUint64(len(p.TypeArguments)))
+	for _, typeArg := range p.TypeArguments {
+		bcs.WriteRawBytes(typeArg.ToBCSBytes())
+	}
 	bcs.EncodeEnum(cast.ToUint64(len(p.Arguments)))
 	for _, a := range p.Arguments {
 		bcs.EncodeEnum(cast.ToUint64(len(a)))
 		bcs.WriteRawBytes(a)
 	}
+}
 
-	return bcs.GetBytes()
+// decodePayload reads a single transaction payload of any supported variant from the decoder.
+func decodePayload(bcs *BCSDecoder) (Payload, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode payload variant")
+	}
+
+	switch variant {
+	case scriptPayloadVariant:
+		payload, err := decodeScriptPayload(bcs)
+		if err != nil {
+			return nil, err
+		}
+
+		return &payload, nil
+	case transactionPayloadVariant:
+		payload, err := decodeTransactionPayload(bcs)
+		if err != nil {
+			return nil, err
+		}
+
+		return &payload, nil
+	case multisigPayloadVariant:
+		payload, err := decodeMultisigPayload(bcs)
+		if err != nil {
+			return nil, err
+		}
+
+		return &payload, nil
+	}
+
+	return nil, errors.Errorf("can't decode payload: unsupported variant %d", variant)
+}
+
+// decodeTransactionPayload decodes the fields of an entry function payload that follow its variant.
+func decodeTransactionPayload(bcs *BCSDecoder) (TransactionPayload, error) {
+	moduleAddress, err := bcs.DecodeAddress()
+	if err != nil {
+		return TransactionPayload{}, errors.Wrap(err, "can't decode payload module address")
+	}
+	moduleName, err := bcs.DecodeString()
+	if err != nil {
+		return TransactionPayload{}, errors.Wrap(err, "can't decode payload module name")
+	}
+	functionName, err := bcs.DecodeString()
+	if err != nil {
+		return TransactionPayload{}, errors.Wrap(err, "can't decode payload function name")
+	}
+	typeArguments, err := decodeTypeTags(bcs, 0)
+	if err != nil {
+		return TransactionPayload{}, errors.Wrap(err, "can't decode payload")
+	}
+	argsLen, err := bcs.DecodeLength()
+	if err != nil {
+		return TransactionPayload{}, errors.Wrap(err, "can't decode payload arguments length")
+	}
+	arguments := make([][]byte, 0, argsLen)
+	for i := 0; i < argsLen; i++ {
+		argument, err := bcs.DecodeBytes()
+		if err != nil {
+			return TransactionPayload{}, errors.Wrapf(err, "can't decode payload argument %d", i)
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return TransactionPayload{
+		ModuleAddress: moduleAddress,
+		ModuleName:    moduleName,
+		FunctionName:  functionName,
+		TypeArguments: typeArguments,
+		Arguments:     arguments,
+	}, nil
 }
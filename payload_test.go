@@ -0,0 +1,61 @@
+package cedra
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTransactionPayloadBCS(t *testing.T) {
+	coin, err := NewStringStructTag(CedraCoin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipient := [32]byte{31: 0xaa}
+	payload := &TransactionPayload{
+		ModuleAddress: cedraFrameworkAddress,
+		ModuleName:    "coin",
+		FunctionName:  "transfer",
+		TypeArguments: []TypeTag{coin, VectorTag{ElementType: U8Tag}},
+		Arguments:     [][]byte{recipient[:], EncodeUintToBCS(uint64(1000))},
+	}
+
+	// Entry function variant, module address, module and function names.
+	want := []byte{0x02}
+	want = append(want, cedraFrameworkAddress[:]...)
+	want = append(want, 0x04, 'c', 'o', 'i', 'n')
+	want = append(want, 0x08, 't', 'r', 'a', 'n', 's', 'f', 'e', 'r')
+	// Two type arguments: the 0x1::cedra_coin::CedraCoin struct and vector<u8>.
+	want = append(want, 0x02, 0x07)
+	want = append(want, cedraFrameworkAddress[:]...)
+	want = append(want, 0x0a, 'c', 'e', 'd', 'r', 'a', '_', 'c', 'o', 'i', 'n')
+	want = append(want, 0x09, 'C', 'e', 'd', 'r', 'a', 'C', 'o', 'i', 'n')
+	want = append(want, 0x00, 0x06, 0x01)
+	// Two length-prefixed arguments: the recipient address and the u64 amount.
+	want = append(want, 0x02, 0x20)
+	want = append(want, recipient[:]...)
+	want = append(want, 0x08, 0xe8, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00)
+	if !bytes.Equal(payload.ToBCSBytes(), want) {
+		t.Fatalf("ToBCSBytes() = %x, want %x", payload.ToBCSBytes(), want)
+	}
+
+	decoded, err := decodePayload(NewBCSDecoder(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryFunction, ok := decoded.(*TransactionPayload)
+	if !ok {
+		t.Fatalf("decodePayload() = %T, want *TransactionPayload", decoded)
+	}
+	if entryFunction.ModuleAddress != payload.ModuleAddress ||
+		entryFunction.ModuleName != payload.ModuleName ||
+		entryFunction.FunctionName != payload.FunctionName ||
+		!reflect.DeepEqual(entryFunction.Arguments, payload.Arguments) {
+		t.Fatalf("decodePayload() = %+v, want %+v", entryFunction, payload)
+	}
+	if len(entryFunction.TypeArguments) != 2 ||
+		entryFunction.TypeArguments[0].String() != "0x1::cedra_coin::CedraCoin" ||
+		entryFunction.TypeArguments[1].String() != "vector<u8>" {
+		t.Fatalf("decoded type arguments = %v, want [0x1::cedra_coin::CedraCoin vector<u8>]", entryFunction.TypeArguments)
+	}
+}
@@ -0,0 +1,392 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// remoteSignerSignPath is the remote signer endpoint that signs a transaction signing message.
+	remoteSignerSignPath = "sign"
+	// remoteSignerPublicKeyPath is the remote signer endpoint that returns the public key of an account.
+	remoteSignerPublicKeyPath = "public_key"
+	// RemoteSignerSchemeEd25519 identifies ED25519 keys in the remote signer protocol.
+	RemoteSignerSchemeEd25519 = "ed25519"
+	// RemoteSignerSchemeSecp256k1 identifies single-key secp256k1 ECDSA keys in the remote signer protocol.
+	RemoteSignerSchemeSecp256k1 = "secp256k1"
+	// RemoteSignerSchemeMultiKey identifies multi-key accounts in the remote signer protocol;
+	// the public key is the encoded multi-key public key returned by MultiKeyAccount.GetPublicKey.
+	RemoteSignerSchemeMultiKey = "multi_key"
+)
+
+// RemoteSignRequest is the body of a remote signer sign request.
+type RemoteSignRequest struct {
+	// Address is the hex-encoded address of the account whose key must sign the message.
+	Address string `json:"address"`
+	// Message is the hex-encoded signing message: the hashed transaction (or transaction with data) prefix
+	// followed by the encoded transaction.
+	Message string `json:"message"`
+}
+
+// RemoteSignResponse is the body of a successful remote signer sign response.
+type RemoteSignResponse struct {
+	// Signature is the hex-encoded signature of the message.
+	Signature string `json:"signature"`
+	// PublicKey is the hex-encoded public key that verifies the signature.
+	PublicKey string `json:"public_key"`
+	// Transaction is the summary of the transaction decoded from the signed message.
+	Transaction TransactionSummary `json:"transaction"`
+}
+
+// RemotePublicKeyResponse is the body of a remote signer public key response.
+type RemotePublicKeyResponse struct {
+	// Address is the hex-encoded account address.
+	Address string `json:"address"`
+	// PublicKey is the hex-encoded public key of the account.
+	PublicKey string `json:"public_key"`
+	// Scheme is the signature scheme of the key ("ed25519", "secp256k1" or "multi_key").
+	Scheme string `json:"scheme"`
+}
+
+// RemoteSignerErrorResponse is the body of a failed remote signer response.
+type RemoteSignerErrorResponse struct {
+	// Message describes why the request failed.
+	Message string `json:"message"`
+}
+
+// TransactionSummary is a human-readable summary of a decoded transaction.
+// The remote signer returns it so that callers and audit logs can see exactly what was signed.
+type TransactionSummary struct {
+	// Sender is the hex-encoded sender address.
+	Sender string `json:"sender"`
+	// SequenceNumber is the sequence number of the sender account.
+	SequenceNumber uint64 `json:"sequence_number"`
+	// PayloadType is the payload kind: "entry_function", "script" or "multisig".
+	PayloadType string `json:"payload_type"`
+	// Function is the called entry function (e.g. "0x1::cedra_account::transfer"), if any.
+	Function string `json:"function,omitempty"`
+	// MultisigAddress is the hex-encoded multisig account address for multisig payloads.
+	MultisigAddress string `json:"multisig_address,omitempty"`
+	// SecondarySigners are the hex-encoded addresses of the secondary signers of multi-agent transactions.
+	SecondarySigners []string `json:"secondary_signers,omitempty"`
+	// FeePayer is the hex-encoded address of the account paying the gas fees of fee payer transactions.
+	FeePayer string `json:"fee_payer,omitempty"`
+	// TypeArguments are the type arguments of the function or script.
+	TypeArguments []string `json:"type_arguments,omitempty"`
+	// Arguments are the hex-encoded BCS arguments of the function or script.
+	Arguments []string `json:"arguments,omitempty"`
+	// MaxGasAmount is the maximum amount of gas units the transaction can consume.
+	MaxGasAmount uint64 `json:"max_gas_amount"`
+	// GasUnitPrice is the price per gas unit.
+	GasUnitPrice uint64 `json:"gas_unit_price"`
+	// ExpirationTimestampSeconds is the Unix timestamp when the transaction expires.
+	ExpirationTimestampSeconds uint64 `json:"expiration_timestamp_secs"`
+	// ChainID identifies the blockchain network.
+	ChainID uint8 `json:"chain_id"`
+}
+
+// NewTransactionSummary creates a human-readable summary of the transaction.
+func NewTransactionSummary(tx Transaction) TransactionSummary {
+	senderAddress := tx.Sender.GetAccountAddress()
+	summary := TransactionSummary{
+		Sender:                     keyPrefix + hex.EncodeToString(senderAddress[:]),
+		SequenceNumber:             tx.SequenceNumber.ToUint64(),
+		MaxGasAmount:               tx.MaxGasAmount.ToUint64(),
+		GasUnitPrice:               tx.GasUnitPrice.ToUint64(),
+		ExpirationTimestampSeconds: tx.ExpirationTimestampSeconds,
+		ChainID:                    tx.ChainId,
+	}
+
+	switch payload := tx.Payload.(type) {
+	case *TransactionPayload:
+		summary.PayloadType = "entry_function"
+		summarizeEntryFunction(&summary, payload)
+	case *ScriptPayload:
+		summary.PayloadType = "script"
+		summary.TypeArguments = typeTagStrings(payload.TypeArguments)
+		for _, a := range payload.Arguments {
+			summary.Arguments = append(summary.Arguments, keyPrefix+hex.EncodeToString(a.ToBCSBytes()))
+		}
+	case *MultisigPayload:
+		summary.PayloadType = "multisig"
+		summary.MultisigAddress = keyPrefix + hex.EncodeToString(payload.MultisigAddress[:])
+		if payload.Payload != nil {
+			summarizeEntryFunction(&summary, payload.Payload)
+		}
+	}
+
+	return summary
+}
+
+// summarizeEntryFunction fills the function, type arguments and arguments of the summary.
+func summarizeEntryFunction(summary *TransactionSummary, payload *TransactionPayload) {
+	summary.Function = addressToString(payload.ModuleAddress) + tagSeparator + payload.ModuleName + tagSeparator + payload.FunctionName
+	summary.TypeArguments = typeTagStrings(payload.TypeArguments)
+	for _, a := range payload.Arguments {
+		summary.Arguments = append(summary.Arguments, keyPrefix+hex.EncodeToString(a))
+	}
+}
+
+// typeTagStrings returns the canonical string forms of the type tags.
+func typeTagStrings(typeTags []TypeTag) []string {
+	values := make([]string, 0, len(typeTags))
+	for _, typeTag := range typeTags {
+		values = append(values, typeTag.String())
+	}
+
+	return values
+}
+
+// RemoteSigner is a Signer that delegates signing to a remote signing service (see RemoteSignerServer),
+// so that private keys never have to be loaded into the calling process.
+type RemoteSigner struct {
+	// signerURL is the base URL of the remote signing service.
+	signerURL url.URL
+	// httpClient is the HTTP client used for making requests to the remote signer.
+	httpClient *http.Client
+	// accountAddress is the address of the account the remote key signs for.
+	accountAddress [32]byte
+	// publicKey is the public key of the remote key.
+	publicKey []byte
+	// scheme is the signature scheme of the remote key.
+	scheme string
+	// multiKey is the multi-key account of the remote key, without signers, for the multi-key scheme.
+	multiKey MultiKeyAccount
+}
+
+// NewRemoteSigner creates a Signer for the account with the given address, backed by the remote signing
+// service at signerURL. The public key of the account is fetched from the service.
+// tlsConfig can be nil to use the default TLS configuration; use NewClientMutualTLSConfig for mutual TLS.
+// The context cancels the public key request.
+// Returns an error if the service can't be reached or doesn't hold a key for the account.
+func NewRemoteSigner(ctx context.Context, signerURL string, address string, tlsConfig *tls.Config) (*RemoteSigner, error) {
+	parsedURL, err := url.Parse(signerURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create remote signer: invalid signer url")
+	}
+	accountAddress, err := NewAccountAddress(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create remote signer")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	signer := &RemoteSigner{
+		signerURL: *parsedURL,
+		httpClient: &http.Client{
+			Timeout:   defaultHTTPTimeout,
+			Transport: transport,
+		},
+		accountAddress: accountAddress,
+	}
+
+	var body io.Reader
+	var headers map[string]string
+	requestURL := signer.signerURL.JoinPath(remoteSignerPublicKeyPath, hex.EncodeToString(accountAddress[:]))
+	response, err := makeRequest[RemotePublicKeyResponse](ctx, http.MethodGet, requestURL, body, headers, signer.httpClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create remote signer: failed to get public key")
+	}
+	publicKey, err := decodeHexBytes(response.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create remote signer: invalid public key")
+	}
+	switch response.Scheme {
+	case RemoteSignerSchemeEd25519:
+		if len(publicKey) != ed25519.PublicKeySize {
+			return nil, errors.New("can't create remote signer: invalid ed25519 public key")
+		}
+	case RemoteSignerSchemeSecp256k1:
+		if _, err := secp256k1.ParsePubKey(publicKey); err != nil {
+			return nil, errors.Wrap(err, "can't create remote signer: invalid secp256k1 public key")
+		}
+	case RemoteSignerSchemeMultiKey:
+		multiKey, err := decodeMultiKeyPublicKey(publicKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't create remote signer: invalid multi-key public key")
+		}
+		if multiKey.AccountAddress != accountAddress {
+			return nil, errors.New("can't create remote signer: multi-key public key doesn't match the account address")
+		}
+		signer.multiKey = multiKey
+	default:
+		return nil, errors.Errorf("can't create remote signer: unsupported key scheme %q", response.Scheme)
+	}
+	signer.publicKey = publicKey
+	signer.scheme = response.Scheme
+
+	return signer, nil
+}
+
+// GetAccountAddress returns the 32-byte account address.
+func (s *RemoteSigner) GetAccountAddress() [32]byte {
+	return s.accountAddress
+}
+
+// GetPublicKey returns the public key of the remote key.
+func (s *RemoteSigner) GetPublicKey() []byte {
+	return s.publicKey
+}
+
+// KeyScheme returns the signature scheme of the remote key: KeySchemeEd25519, KeySchemeSecp256k1 or KeySchemeMultiKey.
+func (s *RemoteSigner) KeyScheme() KeyScheme {
+	switch s.scheme {
+	case RemoteSignerSchemeSecp256k1:
+		return KeySchemeSecp256k1
+	case RemoteSignerSchemeMultiKey:
+		return KeySchemeMultiKey
+	default:
+		return KeySchemeEd25519
+	}
+}
+
+// Sign sends the signing message to the remote signing service and returns the signature.
+// The returned signature is verified against the account's public key before it is accepted.
+// Sign implements Signer and can't be cancelled; SignContext, used when signing transactions, bounds the request.
+func (s *RemoteSigner) Sign(message []byte) ([]byte, error) {
+	return s.SignContext(context.Background(), message)
+}
+
+// SignContext is like Sign, with a context cancelling the request to the remote signing service.
+func (s *RemoteSigner) SignContext(ctx context.Context, message []byte) ([]byte, error) {
+	response, err := s.SignWithSummary(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeHexBytes(response.Signature)
+}
+
+// SignWithSummary sends the signing message to the remote signing service and returns the full response,
+// including the summary of the transaction the service decoded and signed.
+func (s *RemoteSigner) SignWithSummary(ctx context.Context, message []byte) (RemoteSignResponse, error) {
+	requestBody, err := json.Marshal(RemoteSignRequest{
+		Address: keyPrefix + hex.EncodeToString(s.accountAddress[:]),
+		Message: keyPrefix + hex.EncodeToString(message),
+	})
+	if err != nil {
+		return RemoteSignResponse{}, errors.Wrap(err, "can't marshal remote sign request")
+	}
+	headers := map[string]string{
+		"content-type": "application/json",
+	}
+	requestURL := s.signerURL.JoinPath(remoteSignerSignPath)
+	response, err := makeRequest[RemoteSignResponse](ctx, http.MethodPost, requestURL, bytes.NewReader(requestBody), headers, s.httpClient)
+	if err != nil {
+		return RemoteSignResponse{}, errors.Wrap(err, "can't sign message with remote signer")
+	}
+
+	signature, err := decodeHexBytes(response.Signature)
+	if err != nil {
+		return RemoteSignResponse{}, errors.Wrap(err, "can't sign message with remote signer: invalid signature")
+	}
+	if !s.verify(message, signature) {
+		return RemoteSignResponse{}, errors.New("can't sign message with remote signer: signature verification failed")
+	}
+
+	return response, nil
+}
+
+// NewAuthenticator creates the transaction authenticator matching the remote key scheme
+// for a signature produced by Sign.
+func (s *RemoteSigner) NewAuthenticator(signature []byte) CedraAuthenticator {
+	switch s.scheme {
+	case RemoteSignerSchemeSecp256k1:
+		return NewSingleKeyAuthenticator(anyKeySecp256k1Variant, s.publicKey, signature)
+	case RemoteSignerSchemeMultiKey:
+		return s.multiKey.NewAuthenticator(signature)
+	default:
+		return NewCedraAuthenticator(s.publicKey, signature)
+	}
+}
+
+// SimulationSignature returns a zeroed signature with the layout of the remote key's signatures.
+func (s *RemoteSigner) SimulationSignature() []byte {
+	switch s.scheme {
+	case RemoteSignerSchemeSecp256k1:
+		return make([]byte, secp256k1SignatureSize)
+	case RemoteSignerSchemeMultiKey:
+		return s.multiKey.SimulationSignature()
+	default:
+		return make([]byte, ed25519.SignatureSize)
+	}
+}
+
+// verify reports whether the signature of the message is valid for the remote key.
+func (s *RemoteSigner) verify(message []byte, signature []byte) bool {
+	switch s.scheme {
+	case RemoteSignerSchemeSecp256k1:
+		return VerifySecp256k1Signature(s.publicKey, message, signature)
+	case RemoteSignerSchemeMultiKey:
+		return s.multiKey.Verify(message, signature)
+	default:
+		return len(signature) == ed25519.SignatureSize && ed25519.Verify(s.publicKey, message, signature)
+	}
+}
+
+// NewClientMutualTLSConfig creates a TLS configuration for a RemoteSigner that authenticates with the
+// client certificate and only trusts remote signers whose certificate is signed by the given CA.
+func NewClientMutualTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	certificate, caPool, err := loadMutualTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		RootCAs:      caPool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// NewServerMutualTLSConfig creates a TLS configuration for a RemoteSignerServer that presents the server
+// certificate and requires clients to present a certificate signed by the given CA.
+func NewServerMutualTLSConfig(certFile, keyFile, caFile string) (*tls.Config, error) {
+	certificate, caPool, err := loadMutualTLSFiles(certFile, keyFile, caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		ClientCAs:    caPool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+// loadMutualTLSFiles loads a PEM certificate key pair and a PEM CA bundle.
+func loadMutualTLSFiles(certFile, keyFile, caFile string) (tls.Certificate, *x509.CertPool, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, errors.Wrap(err, "can't load tls certificate")
+	}
+	caBytes, err := os.ReadFile(caFile)
+	if err != nil {
+		return tls.Certificate{}, nil, errors.Wrap(err, "can't read tls ca file")
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caBytes) {
+		return tls.Certificate{}, nil, errors.New("can't parse tls ca file")
+	}
+
+	return certificate, caPool, nil
+}
+
+// decodeHexBytes decodes a hex string with an optional "0x" prefix.
+func decodeHexBytes(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(value, keyPrefix))
+}
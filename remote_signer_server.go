@@ -0,0 +1,307 @@
+package cedra
+
+import (
+	"bytes"
+	"crypto/sha3"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxRemoteSignRequestSize limits the size of remote signer request bodies.
+	maxRemoteSignRequestSize = 1 << 20
+	// remoteSignerReadHeaderTimeout is the time allowed to read the request headers of the remote signer HTTP server.
+	remoteSignerReadHeaderTimeout = 5 * time.Second
+	// remoteSignerReadTimeout is the read timeout of the remote signer HTTP server.
+	remoteSignerReadTimeout = 10 * time.Second
+	// remoteSignerWriteTimeout is the time allowed to handle a request and write its response.
+	remoteSignerWriteTimeout = 30 * time.Second
+	// remoteSignerIdleTimeout is the time a keep-alive connection to the remote signer HTTP server is kept idle.
+	remoteSignerIdleTimeout = 2 * time.Minute
+)
+
+// RemoteSignerServer is an http.Handler implementing the remote signer protocol used by RemoteSigner.
+// It holds the signers of one or more accounts, decodes every signing message into a transaction
+// before signing it and returns a summary of the signed transaction.
+//
+// Endpoints (relative to the handler root):
+//
+//	GET  public_key/{address}  returns a RemotePublicKeyResponse
+//	POST sign                  takes a RemoteSignRequest and returns a RemoteSignResponse
+type RemoteSignerServer struct {
+	mu      sync.RWMutex
+	signers map[[32]byte]Signer
+	// Authorize is an optional policy hook called with every decoded transaction before it is signed.
+	// Returning an error rejects the request with 403 Forbidden.
+	Authorize func(tx Transaction) error
+}
+
+// NewRemoteSignerServer creates a remote signer server holding the provided signers.
+func NewRemoteSignerServer(signers ...Signer) *RemoteSignerServer {
+	server := &RemoteSignerServer{
+		signers: make(map[[32]byte]Signer, len(signers)),
+	}
+	for _, signer := range signers {
+		server.AddSigner(signer)
+	}
+
+	return server
+}
+
+// AddSigner registers a signer, replacing any signer previously registered for the same account address.
+func (s *RemoteSignerServer) AddSigner(signer Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signers[signer.GetAccountAddress()] = signer
+}
+
+// ListenAndServe serves the remote signer protocol on the address.
+// If tlsConfig is not nil the server uses TLS; use NewServerMutualTLSConfig to require client certificates.
+func (s *RemoteSignerServer) ListenAndServe(addr string, tlsConfig *tls.Config) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: remoteSignerReadHeaderTimeout,
+		ReadTimeout:       remoteSignerReadTimeout,
+		WriteTimeout:      remoteSignerWriteTimeout,
+		IdleTimeout:       remoteSignerIdleTimeout,
+	}
+	if tlsConfig == nil {
+		return server.ListenAndServe()
+	}
+
+	return server.ListenAndServeTLS("", "")
+}
+
+// ServeHTTP implements http.Handler.
+func (s *RemoteSignerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == remoteSignerSignPath:
+		if r.Method != http.MethodPost {
+			writeRemoteSignerError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+
+			return
+		}
+		s.handleSign(w, r)
+	case strings.HasPrefix(path, remoteSignerPublicKeyPath+"/"):
+		if r.Method != http.MethodGet {
+			writeRemoteSignerError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+
+			return
+		}
+		s.handlePublicKey(w, strings.TrimPrefix(path, remoteSignerPublicKeyPath+"/"))
+	default:
+		writeRemoteSignerError(w, http.StatusNotFound, errors.New("not found"))
+	}
+}
+
+func (s *RemoteSignerServer) handlePublicKey(w http.ResponseWriter, address string) {
+	signer, status, err := s.lookupSigner(address)
+	if err != nil {
+		writeRemoteSignerError(w, status, err)
+
+		return
+	}
+
+	scheme, err := signerScheme(signer)
+	if err != nil {
+		writeRemoteSignerError(w, http.StatusInternalServerError, err)
+
+		return
+	}
+	accountAddress := signer.GetAccountAddress()
+	writeRemoteSignerResponse(w, RemotePublicKeyResponse{
+		Address:   keyPrefix + hex.EncodeToString(accountAddress[:]),
+		PublicKey: keyPrefix + hex.EncodeToString(signer.GetPublicKey()),
+		Scheme:    scheme,
+	})
+}
+
+func (s *RemoteSignerServer) handleSign(w http.ResponseWriter, r *http.Request) {
+	var request RemoteSignRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRemoteSignRequestSize)).Decode(&request); err != nil {
+		writeRemoteSignerError(w, http.StatusBadRequest, errors.Wrap(err, "invalid request body"))
+
+		return
+	}
+	signer, status, err := s.lookupSigner(request.Address)
+	if err != nil {
+		writeRemoteSignerError(w, status, err)
+
+		return
+	}
+	message, err := decodeHexBytes(request.Message)
+	if err != nil {
+		writeRemoteSignerError(w, http.StatusBadRequest, errors.Wrap(err, "invalid message"))
+
+		return
+	}
+
+	signing, err := decodeSigningMessage(message)
+	if err != nil {
+		writeRemoteSignerError(w, http.StatusBadRequest, err)
+
+		return
+	}
+	if !signing.isSigner(signer.GetAccountAddress()) {
+		writeRemoteSignerError(w, http.StatusForbidden, errors.New("requested account is not a signer of the transaction"))
+
+		return
+	}
+	if s.Authorize != nil {
+		if err := s.Authorize(signing.tx); err != nil {
+			writeRemoteSignerError(w, http.StatusForbidden, errors.Wrap(err, "transaction rejected by policy"))
+
+			return
+		}
+	}
+
+	signature, err := signMessage(r.Context(), signer, message)
+	if err != nil {
+		writeRemoteSignerError(w, http.StatusInternalServerError, errors.Wrap(err, "can't sign message"))
+
+		return
+	}
+	writeRemoteSignerResponse(w, RemoteSignResponse{
+		Signature:   keyPrefix + hex.EncodeToString(signature),
+		PublicKey:   keyPrefix + hex.EncodeToString(signer.GetPublicKey()),
+		Transaction: signing.summary(),
+	})
+}
+
+// lookupSigner returns the signer registered for the hex-encoded address and the HTTP status to use on failure.
+func (s *RemoteSignerServer) lookupSigner(address string) (Signer, int, error) {
+	accountAddress, err := NewAccountAddress(address)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.Wrap(err, "invalid address")
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	signer, ok := s.signers[accountAddress]
+	if !ok {
+		return nil, http.StatusNotFound, errors.New("no key for the requested account")
+	}
+
+	return signer, http.StatusOK, nil
+}
+
+// signerScheme returns the remote signer protocol scheme of the signer.
+func signerScheme(signer Signer) (string, error) {
+	switch signer.KeyScheme() {
+	case KeySchemeEd25519:
+		return RemoteSignerSchemeEd25519, nil
+	case KeySchemeSecp256k1:
+		return RemoteSignerSchemeSecp256k1, nil
+	case KeySchemeMultiKey:
+		return RemoteSignerSchemeMultiKey, nil
+	default:
+		return "", errors.Errorf("signer uses an unsupported key scheme %q", signer.KeyScheme())
+	}
+}
+
+// signingMessage is a decoded transaction signing message.
+type signingMessage struct {
+	// tx is the raw transaction.
+	tx Transaction
+	// secondarySigners are the secondary signers of multi-agent transactions.
+	secondarySigners [][32]byte
+	// feePayer is the fee payer of the transaction, if any.
+	feePayer *[32]byte
+}
+
+// isSigner reports whether the account is expected to sign the message.
+func (m signingMessage) isSigner(address [32]byte) bool {
+	return address == m.tx.Sender.GetAccountAddress() ||
+		slices.Contains(m.secondarySigners, address) ||
+		(m.feePayer != nil && address == *m.feePayer)
+}
+
+// summary returns a human-readable summary of the signed transaction.
+func (m signingMessage) summary() TransactionSummary {
+	summary := NewTransactionSummary(m.tx)
+	for _, address := range m.secondarySigners {
+		summary.SecondarySigners = append(summary.SecondarySigners, keyPrefix+hex.EncodeToString(address[:]))
+	}
+	if m.feePayer != nil {
+		summary.FeePayer = keyPrefix + hex.EncodeToString(m.feePayer[:])
+	}
+
+	return summary
+}
+
+// decodeSigningMessage checks that the message is a transaction signing message and decodes the transaction.
+// Arbitrary messages are refused so that the server can't be used to sign anything but transactions.
+func decodeSigningMessage(message []byte) (signingMessage, error) {
+	txPrefix := sha3.Sum256([]byte(transactionPrefix))
+	txWithDataPrefix := sha3.Sum256([]byte(transactionWithDataPrefix))
+	switch {
+	case bytes.HasPrefix(message, txPrefix[:]):
+		tx, err := DecodeTransaction(message[len(txPrefix):])
+		if err != nil {
+			return signingMessage{}, errors.Wrap(err, "can't decode signed transaction")
+		}
+
+		return signingMessage{tx: tx}, nil
+	case bytes.HasPrefix(message, txWithDataPrefix[:]):
+		signing, err := decodeTransactionWithData(NewBCSDecoder(message[len(txWithDataPrefix):]))
+		if err != nil {
+			return signingMessage{}, errors.Wrap(err, "can't decode signed transaction")
+		}
+
+		return signing, nil
+	default:
+		return signingMessage{}, errors.New("message is not a transaction signing message")
+	}
+}
+
+// decodeTransactionWithData decodes fee payer or multi-agent transaction data.
+func decodeTransactionWithData(bcs *BCSDecoder) (signingMessage, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return signingMessage{}, errors.Wrap(err, "can't decode transaction variant")
+	}
+
+	var signing signingMessage
+	switch variant {
+	case multiAgentTransactionVariant:
+		tx, err := decodeMultiAgentTransaction(bcs)
+		if err != nil {
+			return signingMessage{}, err
+		}
+		signing = signingMessage{tx: *tx.Transaction, secondarySigners: tx.SecondarySignerAddresses}
+	case feePayerTransactionVariant:
+		tx, err := decodeFeePayerTransaction(bcs)
+		if err != nil {
+			return signingMessage{}, err
+		}
+		signing = signingMessage{tx: *tx.Transaction, feePayer: &tx.FeePayerAddress}
+	default:
+		return signingMessage{}, errors.Errorf("unsupported transaction variant %d", variant)
+	}
+
+	return signing, bcs.Finish()
+}
+
+func writeRemoteSignerResponse(w http.ResponseWriter, response any) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+func writeRemoteSignerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(RemoteSignerErrorResponse{Message: err.Error()})
+}
@@ -0,0 +1,242 @@
+package cedra
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// postSignRequest sends a sign request for the account to the remote signer server
+// and returns the response status and body.
+func postSignRequest(t *testing.T, serverURL string, address [32]byte, message []byte) (int, []byte) {
+	t.Helper()
+	body, err := json.Marshal(RemoteSignRequest{
+		Address: keyPrefix + hex.EncodeToString(address[:]),
+		Message: keyPrefix + hex.EncodeToString(message),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	response, err := http.Post(serverURL+"/"+remoteSignerSignPath, contentTypeJSON, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+
+	var responseBody bytes.Buffer
+	if _, err := responseBody.ReadFrom(response.Body); err != nil {
+		t.Fatal(err)
+	}
+
+	return response.StatusCode, responseBody.Bytes()
+}
+
+func TestRemoteSignerServerSignsTransactions(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewRemoteSignerServer(account))
+	defer server.Close()
+
+	tx := newTestTransaction(t)
+	status, body := postSignRequest(t, server.URL, account.GetAccountAddress(), tx.SigningMessage())
+	if status != http.StatusOK {
+		t.Fatalf("status = %d (%s), want 200", status, body)
+	}
+	var response RemoteSignResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatal(err)
+	}
+	signature, err := decodeHexBytes(response.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(account.PublicKey, tx.SigningMessage(), signature) {
+		t.Fatal("invalid signature")
+	}
+	if response.Transaction.SequenceNumber != 7 || response.Transaction.Function != "0x1::cedra_account::transfer" {
+		t.Fatalf("summary = %+v, want sequence number 7 calling 0x1::cedra_account::transfer", response.Transaction)
+	}
+}
+
+func TestRemoteSignerServerRejectsRequests(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := newTestTransaction(t)
+	txPrefix := tx.SigningMessage()[:32]
+
+	tests := []struct {
+		name       string
+		authorize  func(tx Transaction) error
+		address    [32]byte
+		message    []byte
+		wantStatus int
+	}{
+		{
+			name:       "arbitrary message",
+			address:    account.GetAccountAddress(),
+			message:    []byte("sign anything"),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "message prefix only",
+			address:    account.GetAccountAddress(),
+			message:    txPrefix,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "transaction with trailing bytes",
+			address:    account.GetAccountAddress(),
+			message:    append(tx.SigningMessage(), 0),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown account",
+			address:    [32]byte{31: 0x42},
+			message:    tx.SigningMessage(),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "account isn't a signer",
+			address:    other.GetAccountAddress(),
+			message:    tx.SigningMessage(),
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "rejected by policy",
+			authorize: func(tx Transaction) error {
+				return errors.New("transfers aren't allowed")
+			},
+			address:    account.GetAccountAddress(),
+			message:    tx.SigningMessage(),
+			wantStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signerServer := NewRemoteSignerServer(account, other)
+			signerServer.Authorize = tt.authorize
+			server := httptest.NewServer(signerServer)
+			defer server.Close()
+
+			status, body := postSignRequest(t, server.URL, tt.address, tt.message)
+			if status != tt.wantStatus {
+				t.Fatalf("status = %d (%s), want %d", status, body, tt.wantStatus)
+			}
+			var response RemoteSignerErrorResponse
+			if err := json.Unmarshal(body, &response); err != nil || response.Message == "" {
+				t.Fatalf("body = %s, want an error message", body)
+			}
+			var signed RemoteSignResponse
+			if err := json.Unmarshal(body, &signed); err != nil || signed.Signature != "" {
+				t.Fatalf("body = %s, want no signature", body)
+			}
+		})
+	}
+}
+
+func TestRemoteSignerServerPassesTransactionToPolicy(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var authorized []Transaction
+	signerServer := NewRemoteSignerServer(account)
+	signerServer.Authorize = func(tx Transaction) error {
+		authorized = append(authorized, tx)
+
+		return nil
+	}
+	server := httptest.NewServer(signerServer)
+	defer server.Close()
+
+	tx := newTestTransaction(t)
+	if status, body := postSignRequest(t, server.URL, account.GetAccountAddress(), tx.SigningMessage()); status != http.StatusOK {
+		t.Fatalf("status = %d (%s), want 200", status, body)
+	}
+	if len(authorized) != 1 || !bytes.Equal(authorized[0].ToBCSBytes(), tx.ToBCSBytes()) {
+		t.Fatalf("policy called with %d transactions, want the signed transaction", len(authorized))
+	}
+}
+
+func TestRemoteSignerServerRejectsWrongMethods(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewRemoteSignerServer(account))
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/" + remoteSignerSignPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("GET sign status = %d, want 405", response.StatusCode)
+	}
+
+	response, err = http.Post(server.URL+"/"+remoteSignerPublicKeyPath+"/"+account.GetAccountAddressString(), contentTypeJSON, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("POST public key status = %d, want 405", response.StatusCode)
+	}
+}
+
+func TestRemoteSignerServerSignsMultiAgentTransactions(t *testing.T) {
+	sender, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondary, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewAccount("0x02" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewRemoteSignerServer(sender, secondary, other))
+	defer server.Close()
+
+	tx := newTestTransaction(t)
+	multiAgentTx := NewMultiAgentTransaction(&tx, secondary.GetAccountAddress())
+	message := multiAgentTx.SigningMessage()
+
+	status, body := postSignRequest(t, server.URL, secondary.GetAccountAddress(), message)
+	if status != http.StatusOK {
+		t.Fatalf("secondary signer status = %d (%s), want 200", status, body)
+	}
+	var response RemoteSignResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatal(err)
+	}
+	signature, err := decodeHexBytes(response.Signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(secondary.PublicKey, message, signature) {
+		t.Fatal("invalid secondary signer signature")
+	}
+
+	if status, body := postSignRequest(t, server.URL, other.GetAccountAddress(), message); status != http.StatusForbidden {
+		t.Fatalf("non-signer status = %d (%s), want 403", status, body)
+	}
+}
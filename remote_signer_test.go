@@ -0,0 +1,106 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestRemoteSignerMultiKey(t *testing.T) {
+	multiKey := newTestMultiKeyAccount(t)
+	server := httptest.NewServer(NewRemoteSignerServer(multiKey))
+	defer server.Close()
+
+	signer, err := NewRemoteSigner(context.Background(), server.URL, multiKey.GetAccountAddressString(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signer.KeyScheme() != KeySchemeMultiKey {
+		t.Fatalf("KeyScheme() = %q, want %q", signer.KeyScheme(), KeySchemeMultiKey)
+	}
+	if !bytes.Equal(simulationSignature(signer), simulationSignature(multiKey)) {
+		t.Fatal("simulation signature doesn't match the multi-key account")
+	}
+
+	tx := newTestTransaction(t)
+	tx.Sender = signer
+	encoded, auth, err := tx.Sign(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiKeyAuth, ok := auth.Auth.(AccountAuthenticator).Auth.(MultiKeyAuth)
+	if !ok {
+		t.Fatalf("authenticator = %+v, want a multi-key authenticator", auth)
+	}
+	if !reflect.DeepEqual(auth, multiKey.NewAuthenticator(multiKeyAuth.Signature)) {
+		t.Fatal("authenticator doesn't match the multi-key account")
+	}
+	if !multiKey.Verify(tx.SigningMessage(), multiKeyAuth.Signature) {
+		t.Fatal("invalid multi-key signature")
+	}
+	if _, _, err := DecodeSignedTransaction(EncodeSignedTransaction(encoded, auth)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiKeyAccountVerify(t *testing.T) {
+	multiKey := newTestMultiKeyAccount(t)
+	message := []byte("message")
+	signature, err := multiKey.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !multiKey.Verify(message, signature) {
+		t.Fatal("valid signature rejected")
+	}
+	if multiKey.Verify([]byte("other"), signature) {
+		t.Fatal("signature of another message accepted")
+	}
+	if multiKey.Verify(message, multiKey.SimulationSignature()) {
+		t.Fatal("zeroed signature accepted")
+	}
+	if multiKey.Verify(message, append(signature, 0)) {
+		t.Fatal("signature with trailing bytes accepted")
+	}
+}
+
+func TestSigningIsCancelledByContext(t *testing.T) {
+	account, err := NewAccount(testPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(NewRemoteSignerServer(account))
+	defer server.Close()
+	signer, err := NewRemoteSigner(context.Background(), server.URL, account.GetAccountAddressString(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feePayer, err := NewAccount("0x01" + "00000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tx := newTestTransaction(t)
+	tx.Sender = signer
+	if _, _, err := tx.Sign(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Transaction.Sign() error = %v, want context.Canceled", err)
+	}
+	feePayerTx := NewFeePayerTransaction(&tx, feePayer.GetAccountAddress())
+	if _, err := feePayerTx.SignAs(ctx, signer); !errors.Is(err, context.Canceled) {
+		t.Fatalf("FeePayerTransaction.SignAs() error = %v, want context.Canceled", err)
+	}
+	multiAgentTx := NewMultiAgentTransaction(&tx, feePayer.GetAccountAddress())
+	if _, err := multiAgentTx.SignAs(ctx, signer); !errors.Is(err, context.Canceled) {
+		t.Fatalf("MultiAgentTransaction.SignAs() error = %v, want context.Canceled", err)
+	}
+
+	if _, _, err := tx.Sign(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,134 @@
+package cedra
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy configures how requests to the node are retried after transient failures:
+// rate limiting (429), unavailability (503), gateway errors (502, 504) and connection resets.
+// The zero value disables retries.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first one.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles after every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Jitter is the fraction of the delay, between 0 and 1, that is randomly removed from it
+	// so that concurrent clients don't retry in lockstep.
+	Jitter float64
+	// MaxRetryAfter is the longest Retry-After delay requested by the node that is honoured;
+	// the request fails instead of waiting longer. Zero means no limit.
+	MaxRetryAfter time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by nodes created without WithRetryPolicy.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Jitter:         0.5,
+		MaxRetryAfter:  30 * time.Second,
+	}
+}
+
+// backoff returns the delay before the retry following the attempt, starting at 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for range attempt - 1 {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		delay -= time.Duration(rand.Float64() * min(p.Jitter, 1) * float64(delay))
+	}
+
+	return delay
+}
+
+// retryDelay reports whether the failed request can be retried and the delay to wait before retrying it.
+func (p RetryPolicy) retryDelay(err error, attempt int) (time.Duration, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case isRetryableStatus(apiErr.StatusCode):
+		case apiErr.StatusCode == http.StatusBadGateway || apiErr.StatusCode == http.StatusGatewayTimeout:
+		default:
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			if p.MaxRetryAfter > 0 && apiErr.RetryAfter > p.MaxRetryAfter {
+				return 0, false
+			}
+
+			return apiErr.RetryAfter, true
+		}
+
+		return p.backoff(attempt), true
+	}
+
+	if isConnectionError(err) {
+		return p.backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// isRetryableStatus reports whether the node rejected the request without processing it
+// because of rate limiting or unavailability.
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// isConnectionError reports whether the request failed because of the connection to the node,
+// e.g. a connection reset, refused connection or timeout.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retryRequest performs the request, retrying it according to the policy while it fails with a transient error.
+// Returns the last error if the request can't be retried anymore or the context is done.
+func retryRequest[T any](ctx context.Context, policy RetryPolicy, request func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := request()
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			return response, err
+		}
+		delay, ok := policy.retryDelay(err, attempt)
+		if !ok {
+			return response, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return response, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package cedra
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 100 * time.Millisecond},
+		{attempt: 2, want: 200 * time.Millisecond},
+		{attempt: 3, want: 400 * time.Millisecond},
+		{attempt: 4, want: 800 * time.Millisecond},
+		{attempt: 5, want: time.Second},
+		{attempt: 100, want: time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffJitter(t *testing.T) {
+	tests := []struct {
+		name   string
+		jitter float64
+		min    time.Duration
+	}{
+		{name: "half", jitter: 0.5, min: 400 * time.Millisecond},
+		{name: "full", jitter: 1, min: 0},
+		{name: "above one", jitter: 2, min: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := RetryPolicy{InitialBackoff: 200 * time.Millisecond, MaxBackoff: time.Second, Jitter: tt.jitter}
+			for range 100 {
+				// The third attempt waits 800ms before jitter.
+				if got := policy.backoff(3); got < tt.min || got > 800*time.Millisecond {
+					t.Fatalf("backoff(3) = %v, want between %v and 800ms", got, tt.min)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyRetryDelay(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, MaxRetryAfter: 10 * time.Second}
+	tests := []struct {
+		name      string
+		err       error
+		wantDelay time.Duration
+		wantRetry bool
+	}{
+		{name: "429", err: &APIError{StatusCode: http.StatusTooManyRequests}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "503", err: &APIError{StatusCode: http.StatusServiceUnavailable}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "wrapped 503", err: errors.Wrap(&APIError{StatusCode: http.StatusServiceUnavailable}, "request"), wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "502", err: &APIError{StatusCode: http.StatusBadGateway}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "504", err: &APIError{StatusCode: http.StatusGatewayTimeout}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "500", err: &APIError{StatusCode: http.StatusInternalServerError}},
+		{name: "400", err: &APIError{StatusCode: http.StatusBadRequest}},
+		{name: "connection reset", err: &net.OpError{Op: "read", Err: syscall.ECONNRESET}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "eof", err: errors.Wrap(io.EOF, "request"), wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "timeout", err: timeoutError{}, wantDelay: 100 * time.Millisecond, wantRetry: true},
+		{name: "other error", err: errors.New("invalid json")},
+		{name: "retry after", err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 3 * time.Second}, wantDelay: 3 * time.Second, wantRetry: true},
+		{name: "retry after at limit", err: &APIError{StatusCode: http.StatusServiceUnavailable, RetryAfter: 10 * time.Second}, wantDelay: 10 * time.Second, wantRetry: true},
+		{name: "retry after over limit", err: &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 11 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, retry := policy.retryDelay(tt.err, 1)
+			if delay != tt.wantDelay || retry != tt.wantRetry {
+				t.Fatalf("retryDelay() = %v, %v, want %v, %v", delay, retry, tt.wantDelay, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{name: "empty", value: "", want: 0},
+		{name: "seconds", value: "120", want: 2 * time.Minute},
+		{name: "zero seconds", value: "0", want: 0},
+		{name: "negative seconds", value: "-5", want: 0},
+		{name: "invalid", value: "soon", want: 0},
+		{name: "past date", value: "Wed, 21 Oct 2015 07:28:00 GMT", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value); got != tt.want {
+				t.Fatalf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("future date", func(t *testing.T) {
+		got := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		// The date has a precision of one second.
+		if got <= 58*time.Second || got > time.Minute {
+			t.Fatalf("parseRetryAfter() = %v, want about 1m", got)
+		}
+	})
+}
+
+func TestSubmitTransactionRecoversLostResponse(t *testing.T) {
+	tx := newTestTransaction(t)
+	encoded, auth, err := tx.Sign(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTx := EncodeSignedTransaction(encoded, auth)
+	hash := signedTransactionHash(signedTx)
+
+	var submissions, lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/transactions":
+			if submissions.Add(1) == 1 {
+				// The node accepts the transaction but the response is lost.
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Error(err)
+
+					return
+				}
+				conn.Close()
+
+				return
+			}
+			// The resubmission is rejected as the transaction is already known.
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"message":    "Transaction already in mempool",
+				"error_code": "invalid_transaction_update",
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/transactions/by_hash/"+hash:
+			lookups.Add(1)
+			_ = json.NewEncoder(w).Encode(map[string]string{"type": "pending_transaction", "hash": hash})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	node, err := NewCedraNode(TestnetChainID,
+		WithNodeURL(server.URL+"/v1/"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := node.SubmitTransaction(context.Background(), signedTx)
+	if err != nil {
+		t.Fatalf("SubmitTransaction() error = %v", err)
+	}
+	if got != hash {
+		t.Fatalf("SubmitTransaction() = %s, want %s", got, hash)
+	}
+	if TransactionHash(encoded, auth) != got {
+		t.Fatalf("TransactionHash() = %s, want the hash echoed by the node %s", TransactionHash(encoded, auth), got)
+	}
+	if submissions.Load() != 2 || lookups.Load() != 1 {
+		t.Fatalf("%d submissions and %d lookups, want 2 and 1", submissions.Load(), lookups.Load())
+	}
+}
+
+func TestSubmitTransactionReportsRejection(t *testing.T) {
+	var submissions atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/transactions/by_hash/") {
+			t.Error("a transaction rejected on the first attempt must not be looked up")
+		}
+		submissions.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid signature", "error_code": "invalid_input"})
+	}))
+	defer server.Close()
+
+	node, err := NewCedraNode(TestnetChainID,
+		WithNodeURL(server.URL+"/v1/"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = node.SubmitTransaction(context.Background(), []byte{1, 2, 3})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("SubmitTransaction() error = %v, want a 400 APIError", err)
+	}
+	if submissions.Load() != 1 {
+		t.Fatalf("%d submissions, want 1", submissions.Load())
+	}
+}
+
+func TestSubmitTransactionLooksUpTransactionOnce(t *testing.T) {
+	tx := newTestTransaction(t)
+	encoded, auth, err := tx.Sign(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedTx := EncodeSignedTransaction(encoded, auth)
+
+	var submissions, lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v1/transactions/by_hash/") {
+			// The lookup fails with a transient error, which must not start another round of retries.
+			lookups.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		if submissions.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid signature", "error_code": "invalid_input"})
+	}))
+	defer server.Close()
+
+	node, err := NewCedraNode(TestnetChainID,
+		WithNodeURL(server.URL+"/v1/"),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := node.SubmitTransaction(context.Background(), signedTx); err == nil {
+		t.Fatal("expected an error")
+	}
+	if submissions.Load() != 2 || lookups.Load() != 1 {
+		t.Fatalf("%d submissions and %d lookups, want 2 and 1", submissions.Load(), lookups.Load())
+	}
+}
@@ -0,0 +1,239 @@
+package cedra
+
+import (
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+const (
+	// scriptPayloadVariant is the variant identifier for script payloads.
+	scriptPayloadVariant = 0
+)
+
+const (
+	// TransactionArgumentU8 is the variant identifier for u8 script arguments.
+	TransactionArgumentU8 uint64 = 0
+	// TransactionArgumentU64 is the variant identifier for u64 script arguments.
+	TransactionArgumentU64 uint64 = 1
+	// TransactionArgumentU128 is the variant identifier for u128 script arguments.
+	TransactionArgumentU128 uint64 = 2
+	// TransactionArgumentAddress is the variant identifier for address script arguments.
+	TransactionArgumentAddress uint64 = 3
+	// TransactionArgumentU8Vector is the variant identifier for vector<u8> script arguments.
+	TransactionArgumentU8Vector uint64 = 4
+	// TransactionArgumentBool is the variant identifier for bool script arguments.
+	TransactionArgumentBool uint64 = 5
+	// TransactionArgumentU16 is the variant identifier for u16 script arguments.
+	TransactionArgumentU16 uint64 = 6
+	// TransactionArgumentU32 is the variant identifier for u32 script arguments.
+	TransactionArgumentU32 uint64 = 7
+	// TransactionArgumentU256 is the variant identifier for u256 script arguments.
+	TransactionArgumentU256 uint64 = 8
+	// TransactionArgumentSerialized is the variant identifier for script arguments of any other type,
+	// passed as their BCS-encoded bytes.
+	TransactionArgumentSerialized uint64 = 9
+)
+
+// transactionArgumentSizes maps the fixed-size script argument variants to the size of their value.
+var transactionArgumentSizes = map[uint64]int{
+	TransactionArgumentU8:      1,
+	TransactionArgumentU64:     8,
+	TransactionArgumentU128:    16,
+	TransactionArgumentAddress: 32,
+	TransactionArgumentU16:     2,
+	TransactionArgumentU32:     4,
+	TransactionArgumentU256:    32,
+}
+
+// ScriptPayload represents the script payload of a Cedra transaction.
+// It carries compiled Move script bytecode together with its type arguments and arguments.
+type ScriptPayload struct {
+	// Code is the compiled Move script bytecode.
+	Code []byte
+	// TypeArguments are the type arguments of a generic script.
+	TypeArguments []TypeTag
+	// Arguments are the typed arguments passed to the script.
+	Arguments []TransactionArgument
+}
+
+// ToBCSBytes encodes the script payload into Binary Canonical Serialization (BCS) format.
+// Returns the serialized byte representation of the payload.
+func (p *ScriptPayload) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(scriptPayloadVariant)
+	bcs.EncodeBytes(p.Code)
+	bcs.EncodeEnum(cast.ToUint64(len(p.TypeArguments)))
+	for _, typeArg := range p.TypeArguments {
+		bcs.WriteRawBytes(typeArg.ToBCSBytes())
+	}
+	bcs.EncodeEnum(cast.ToUint64(len(p.Arguments)))
+	for _, a := range p.Arguments {
+		bcs.WriteRawBytes(a.ToBCSBytes())
+	}
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes a script payload encoded with ToBCSBytes.
+func (p *ScriptPayload) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(scriptPayloadVariant, "script payload"); err != nil {
+		return err
+	}
+	payload, err := decodeScriptPayload(bcs)
+	if err != nil {
+		return err
+	}
+	*p = payload
+
+	return nil
+}
+
+// TransactionArgument represents a typed argument of a Move script.
+type TransactionArgument struct {
+	// Variant specifies the argument type (see the TransactionArgument* constants).
+	Variant uint64
+	// Value is the BCS-encoded argument value.
+	Value []byte
+}
+
+// ToBCSBytes encodes the argument into Binary Canonical Serialization (BCS) format.
+func (a TransactionArgument) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(a.Variant)
+	bcs.WriteRawBytes(a.Value)
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes a script argument encoded with ToBCSBytes.
+func (a *TransactionArgument) UnmarshalBCS(bcs *BCSDecoder) error {
+	argument, err := decodeTransactionArgument(bcs)
+	if err != nil {
+		return err
+	}
+	*a = argument
+
+	return nil
+}
+
+// NewU8Argument creates a u8 script argument.
+func NewU8Argument(value uint8) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU8, Value: EncodeUintToBCS(value)}
+}
+
+// NewU16Argument creates a u16 script argument.
+func NewU16Argument(value uint16) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU16, Value: EncodeUintToBCS(value)}
+}
+
+// NewU32Argument creates a u32 script argument.
+func NewU32Argument(value uint32) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU32, Value: EncodeUintToBCS(value)}
+}
+
+// NewU64Argument creates a u64 script argument.
+func NewU64Argument(value uint64) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU64, Value: EncodeUintToBCS(value)}
+}
+
+// NewU128Argument creates a u128 script argument.
+func NewU128Argument(value Uint128) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU128, Value: EncodeUintToBCS(value)}
+}
+
+// NewU256Argument creates a u256 script argument.
+func NewU256Argument(value Uint256) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU256, Value: EncodeUintToBCS(value)}
+}
+
+// NewAddressArgument creates an address script argument.
+func NewAddressArgument(address [32]byte) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentAddress, Value: address[:]}
+}
+
+// NewU8VectorArgument creates a vector<u8> script argument.
+func NewU8VectorArgument(value []byte) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentU8Vector, Value: EncodeToBCSBytes(value)}
+}
+
+// NewBoolArgument creates a bool script argument.
+func NewBoolArgument(value bool) TransactionArgument {
+	encoded := []byte{0}
+	if value {
+		encoded[0] = 1
+	}
+
+	return TransactionArgument{Variant: TransactionArgumentBool, Value: encoded}
+}
+
+// NewSerializedArgument creates a script argument of any other type from its BCS-encoded bytes,
+// e.g. the output of Marshal for a vector<address> or a struct.
+func NewSerializedArgument(encoded []byte) TransactionArgument {
+	return TransactionArgument{Variant: TransactionArgumentSerialized, Value: EncodeToBCSBytes(encoded)}
+}
+
+// decodeScriptPayload decodes the fields of a script payload that follow its variant.
+func decodeScriptPayload(bcs *BCSDecoder) (ScriptPayload, error) {
+	code, err := bcs.DecodeBytes()
+	if err != nil {
+		return ScriptPayload{}, errors.Wrap(err, "can't decode script code")
+	}
+	typeArguments, err := decodeTypeTags(bcs, 0)
+	if err != nil {
+		return ScriptPayload{}, errors.Wrap(err, "can't decode script")
+	}
+	argsLen, err := bcs.DecodeLength()
+	if err != nil {
+		return ScriptPayload{}, errors.Wrap(err, "can't decode script arguments length")
+	}
+	arguments := make([]TransactionArgument, 0, argsLen)
+	for i := 0; i < argsLen; i++ {
+		argument, err := decodeTransactionArgument(bcs)
+		if err != nil {
+			return ScriptPayload{}, errors.Wrapf(err, "can't decode script argument %d", i)
+		}
+		arguments = append(arguments, argument)
+	}
+
+	return ScriptPayload{
+		Code:          code,
+		TypeArguments: typeArguments,
+		Arguments:     arguments,
+	}, nil
+}
+
+// decodeTransactionArgument reads a single script argument from the decoder.
+func decodeTransactionArgument(bcs *BCSDecoder) (TransactionArgument, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return TransactionArgument{}, errors.Wrap(err, "can't decode argument variant")
+	}
+
+	var value []byte
+	switch variant {
+	case TransactionArgumentU8Vector, TransactionArgumentSerialized:
+		var bytes []byte
+		bytes, err = bcs.DecodeBytes()
+		value = EncodeToBCSBytes(bytes)
+	case TransactionArgumentBool:
+		var flag bool
+		flag, err = bcs.DecodeBool()
+		value = NewBoolArgument(flag).Value
+	default:
+		size, ok := transactionArgumentSizes[variant]
+		if !ok {
+			return TransactionArgument{}, errors.Errorf("can't decode argument: unsupported variant %d", variant)
+		}
+		value, err = bcs.ReadRawBytes(size)
+	}
+	if err != nil {
+		return TransactionArgument{}, errors.Wrap(err, "can't decode argument value")
+	}
+
+	return TransactionArgument{
+		Variant: variant,
+		Value:   value,
+	}, nil
+}
@@ -0,0 +1,97 @@
+package cedra
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestTransactionArgumentBCS(t *testing.T) {
+	tests := []struct {
+		name     string
+		argument TransactionArgument
+		want     []byte
+	}{
+		{name: "u8", argument: NewU8Argument(7), want: []byte{0x00, 0x07}},
+		{name: "u64", argument: NewU64Argument(1000), want: []byte{0x01, 0xe8, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{
+			name:     "u128",
+			argument: NewU128Argument(NewUint128FromUint64(0x0102)),
+			want:     append([]byte{0x02, 0x02, 0x01}, make([]byte, 14)...),
+		},
+		{name: "address", argument: NewAddressArgument(cedraFrameworkAddress), want: append([]byte{0x03}, cedraFrameworkAddress[:]...)},
+		{name: "u8 vector", argument: NewU8VectorArgument([]byte{0xca, 0xfe}), want: []byte{0x04, 0x02, 0xca, 0xfe}},
+		{name: "empty u8 vector", argument: NewU8VectorArgument(nil), want: []byte{0x04, 0x00}},
+		{name: "true", argument: NewBoolArgument(true), want: []byte{0x05, 0x01}},
+		{name: "false", argument: NewBoolArgument(false), want: []byte{0x05, 0x00}},
+		{name: "u16", argument: NewU16Argument(0x0102), want: []byte{0x06, 0x02, 0x01}},
+		{name: "u32", argument: NewU32Argument(0x01020304), want: []byte{0x07, 0x04, 0x03, 0x02, 0x01}},
+		{name: "u256", argument: NewU256Argument(NewUint256FromUint64(1)), want: append([]byte{0x08, 0x01}, make([]byte, 31)...)},
+		{name: "serialized", argument: NewSerializedArgument([]byte{0x01, 0x02}), want: []byte{0x09, 0x02, 0x01, 0x02}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.argument.ToBCSBytes(); !bytes.Equal(got, tt.want) {
+				t.Fatalf("ToBCSBytes() = %x, want %x", got, tt.want)
+			}
+
+			bcs := NewBCSDecoder(tt.want)
+			decoded, err := decodeTransactionArgument(bcs)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bcs.Finish(); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.Variant != tt.argument.Variant || !bytes.Equal(decoded.Value, tt.argument.Value) {
+				t.Fatalf("decodeTransactionArgument() = %+v, want %+v", decoded, tt.argument)
+			}
+		})
+	}
+}
+
+func TestDecodeTransactionArgumentRejectsInvalidInput(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "unknown variant", data: []byte{0x0a, 0x00}},
+		{name: "truncated u64", data: []byte{0x01, 0x01, 0x02}},
+		{name: "truncated address", data: append([]byte{0x03}, make([]byte, 31)...)},
+		{name: "u8 vector over remaining", data: []byte{0x04, 0x03, 0x01}},
+		{name: "invalid bool", data: []byte{0x05, 0x02}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeTransactionArgument(NewBCSDecoder(tt.data)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestScriptPayloadBCS(t *testing.T) {
+	payload := &ScriptPayload{
+		Code:          []byte{0xa1, 0x1c, 0xeb, 0x0b},
+		TypeArguments: []TypeTag{U64Tag},
+		Arguments:     []TransactionArgument{NewAddressArgument(cedraFrameworkAddress), NewBoolArgument(true)},
+	}
+
+	// Script variant, length-prefixed code, one type argument and two arguments.
+	want := []byte{0x00, 0x04, 0xa1, 0x1c, 0xeb, 0x0b, 0x01, 0x02, 0x02, 0x03}
+	want = append(want, cedraFrameworkAddress[:]...)
+	want = append(want, 0x05, 0x01)
+	if !bytes.Equal(payload.ToBCSBytes(), want) {
+		t.Fatalf("ToBCSBytes() = %x, want %x", payload.ToBCSBytes(), want)
+	}
+
+	decoded, err := decodePayload(NewBCSDecoder(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(decoded, payload) {
+		t.Fatalf("decodePayload() = %+v, want %+v", decoded, payload)
+	}
+}
@@ -0,0 +1,129 @@
+package cedra
+
+import (
+	"crypto/sha3"
+	"encoding/hex"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/pkg/errors"
+)
+
+const (
+	// secp256k1PrivateKeyPrefix is the prefix used for secp256k1 private keys.
+	secp256k1PrivateKeyPrefix = "secp256k1-priv-"
+	// secp256k1SignatureSize is the size of a secp256k1 ECDSA signature (r || s).
+	secp256k1SignatureSize = 64
+)
+
+// Secp256k1Account represents a Cedra single-key account backed by a secp256k1 ECDSA key,
+// e.g. a key imported from an EVM wallet. It is an in-memory implementation of Signer.
+type Secp256k1Account struct {
+	// AccountAddress is the 32-byte account address derived from the public key.
+	AccountAddress [32]byte
+	// PrivateKey is the secp256k1 private key used for signing transactions.
+	PrivateKey *secp256k1.PrivateKey
+	// PublicKey is the 65-byte uncompressed secp256k1 public key associated with the account.
+	PublicKey []byte
+}
+
+// NewSecp256k1Account creates a new Secp256k1Account from a hexadecimal private key string.
+// The hexKey can optionally include the "secp256k1-priv-" prefix and/or "0x" prefix.
+// Returns an error if the key format is invalid or cannot be parsed.
+func NewSecp256k1Account(hexKey string) (Secp256k1Account, error) {
+	hexKey = strings.TrimPrefix(hexKey, secp256k1PrivateKeyPrefix)
+	hexKey = strings.TrimPrefix(hexKey, keyPrefix)
+
+	privBytes, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return Secp256k1Account{}, err
+	}
+	if len(privBytes) != secp256k1.PrivKeyBytesLen {
+		return Secp256k1Account{}, errors.New("can't create secp256k1 account: private key must be 32 bytes")
+	}
+
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(privBytes); overflow || scalar.IsZero() {
+		return Secp256k1Account{}, errors.New("can't create secp256k1 account: invalid private key")
+	}
+	privateKey := secp256k1.NewPrivateKey(&scalar)
+	publicKey := privateKey.PubKey().SerializeUncompressed()
+
+	return Secp256k1Account{
+		PrivateKey:     privateKey,
+		PublicKey:      publicKey,
+		AccountAddress: deriveAccountAddress(encodeAnyPublicKey(anyKeySecp256k1Variant, publicKey), singleKeySchema),
+	}, nil
+}
+
+// GetAccountAddress returns the 32-byte account address.
+func (a Secp256k1Account) GetAccountAddress() [32]byte {
+	return a.AccountAddress
+}
+
+// GetAccountAddressString returns the hexadecimal string representation of the account address.
+func (a Secp256k1Account) GetAccountAddressString() string {
+	return hex.EncodeToString(a.AccountAddress[:])
+}
+
+// GetPublicKey returns the uncompressed secp256k1 public key of the account.
+func (a Secp256k1Account) GetPublicKey() []byte {
+	return a.PublicKey
+}
+
+// KeyScheme returns KeySchemeSecp256k1.
+func (a Secp256k1Account) KeyScheme() KeyScheme {
+	return KeySchemeSecp256k1
+}
+
+// Sign signs the SHA3-256 hash of the message with the account's secp256k1 private key.
+// Returns the 64-byte r || s signature, normalized to a low S value as required by the chain.
+func (a Secp256k1Account) Sign(message []byte) ([]byte, error) {
+	if a.PrivateKey == nil {
+		return nil, errors.New("can't sign message: account has no private key")
+	}
+	hash := sha3.Sum256(message)
+	// The compact signature is prefixed with a recovery code that the chain doesn't use.
+	compact := ecdsa.SignCompact(a.PrivateKey, hash[:], false)
+
+	return compact[1:], nil
+}
+
+// Verify reports whether the signature is a valid signature of the message by the account.
+func (a Secp256k1Account) Verify(message []byte, signature []byte) bool {
+	return VerifySecp256k1Signature(a.PublicKey, message, signature)
+}
+
+// NewAuthenticator creates a single-key secp256k1 transaction authenticator for a signature produced by Sign.
+func (a Secp256k1Account) NewAuthenticator(signature []byte) CedraAuthenticator {
+	return NewSingleKeyAuthenticator(anyKeySecp256k1Variant, a.PublicKey, signature)
+}
+
+// SimulationSignature returns a zeroed secp256k1 signature.
+func (a Secp256k1Account) SimulationSignature() []byte {
+	return make([]byte, secp256k1SignatureSize)
+}
+
+// VerifySecp256k1Signature reports whether the 64-byte r || s signature is a valid secp256k1 ECDSA signature
+// of the SHA3-256 hash of the message by the public key. High S signatures are rejected as malleable.
+func VerifySecp256k1Signature(publicKey []byte, message []byte, signature []byte) bool {
+	if len(signature) != secp256k1SignatureSize {
+		return false
+	}
+	pubKey, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+
+	var r, s secp256k1.ModNScalar
+	if overflow := r.SetByteSlice(signature[:32]); overflow || r.IsZero() {
+		return false
+	}
+	if overflow := s.SetByteSlice(signature[32:]); overflow || s.IsZero() || s.IsOverHalfOrder() {
+		return false
+	}
+	hash := sha3.Sum256(message)
+
+	return ecdsa.NewSignature(&r, &s).Verify(hash[:], pubKey)
+}
@@ -0,0 +1,151 @@
+package cedra
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+const (
+	// testSecp256k1PrivateKey is the secp256k1 private key 1, whose public key is the generator point.
+	testSecp256k1PrivateKey = "secp256k1-priv-0x0000000000000000000000000000000000000000000000000000000000000001"
+	// testSecp256k1PublicKey is the uncompressed public key of testSecp256k1PrivateKey.
+	testSecp256k1PublicKey = "04" +
+		"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798" +
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"
+)
+
+// mustDecodeHex decodes a hexadecimal test vector.
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func TestSecp256k1AccountAddress(t *testing.T) {
+	account, err := NewSecp256k1Account(testSecp256k1PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(account.PublicKey); got != testSecp256k1PublicKey {
+		t.Fatalf("PublicKey = %s, want %s", got, testSecp256k1PublicKey)
+	}
+
+	// sha3-256 of the AnyPublicKey (variant 1, length-prefixed key) followed by the single-key scheme byte.
+	want := "d27beca0d8d20fa2ce444c7beab733b080d020711abcb65b2cb991868fb8fddb"
+	if got := account.GetAccountAddressString(); got != want {
+		t.Fatalf("GetAccountAddressString() = %s, want %s", got, want)
+	}
+}
+
+func TestNewSecp256k1AccountRejectsInvalidKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "not hex", key: "0xzz"},
+		{name: "too short", key: "0x01"},
+		{name: "zero", key: "0x" + "0000000000000000000000000000000000000000000000000000000000000000"},
+		{name: "curve order", key: "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewSecp256k1Account(tt.key); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestSecp256k1AccountSign(t *testing.T) {
+	account, err := NewSecp256k1Account(testSecp256k1PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("cedra")
+
+	// RFC 6979 signature of sha3-256("cedra"), normalized to low S.
+	want := "a43930311d5aa63e162cb9954ea2c83f8ae3de645f0da7fa7b70031f5451dfda" +
+		"3aa54482a389be18c92d4d0adf5c3764afbc06e3f2a42bb5c7924120cad45259"
+	signature, err := account.Sign(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(signature); got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+	if !account.Verify(message, signature) {
+		t.Fatal("Verify() = false for the account's own signature")
+	}
+	if account.Verify([]byte("cedrb"), signature) {
+		t.Fatal("Verify() = true for a different message")
+	}
+
+	// The same signature with S replaced by N - S is valid ECDSA but malleable, and must be rejected.
+	var s secp256k1.ModNScalar
+	s.SetByteSlice(signature[32:])
+	highS := s.Negate().Bytes()
+	malleable := append(bytes.Clone(signature[:32]), highS[:]...)
+	if hex.EncodeToString(highS[:]) != "c55abb7d5c7641e736d2b2f520a3c89a0af2d602bca47485f8401d6c0561eee8" {
+		t.Fatalf("N - S = %x", highS)
+	}
+	if account.Verify(message, malleable) {
+		t.Fatal("Verify() = true for a high S signature")
+	}
+}
+
+func TestSecp256k1AccountSignsLowS(t *testing.T) {
+	account, err := NewSecp256k1Account(testSecp256k1PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 64 {
+		message := []byte{byte(i)}
+		signature, err := account.Sign(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s secp256k1.ModNScalar
+		s.SetByteSlice(signature[32:])
+		if s.IsOverHalfOrder() {
+			t.Fatalf("Sign(%x) returned a high S signature %x", message, signature)
+		}
+		if !VerifySecp256k1Signature(account.PublicKey, message, signature) {
+			t.Fatalf("VerifySecp256k1Signature() = false for message %x", message)
+		}
+	}
+}
+
+func TestSecp256k1AccountAuthenticator(t *testing.T) {
+	account, err := NewSecp256k1Account(testSecp256k1PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := bytes.Repeat([]byte{0xab}, secp256k1SignatureSize)
+
+	// Single sender variant, single-key account authenticator variant, then the AnyPublicKey
+	// and AnySignature, both with the secp256k1 variant and a length-prefixed value.
+	want := []byte{0x04, 0x02, 0x01, 0x41}
+	want = append(want, mustDecodeHex(t, testSecp256k1PublicKey)...)
+	want = append(want, 0x01, 0x40)
+	want = append(want, signature...)
+	authenticator := account.NewAuthenticator(signature)
+	if got := authenticator.EncodeBSC(); !bytes.Equal(got, want) {
+		t.Fatalf("EncodeBSC() = %x, want %x", got, want)
+	}
+
+	decoded, err := DecodeCedraAuthenticator(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(decoded.EncodeBSC(), want) {
+		t.Fatalf("decoded authenticator encodes to %x, want %x", decoded.EncodeBSC(), want)
+	}
+}
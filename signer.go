@@ -0,0 +1,63 @@
+package cedra
+
+import "context"
+
+// KeyScheme identifies the signature scheme of the key of a Signer, which determines the
+// authenticator it builds and the public key type it exposes.
+type KeyScheme string
+
+const (
+	// KeySchemeEd25519 is a single ED25519 key, e.g. an Account.
+	KeySchemeEd25519 KeyScheme = "ed25519"
+	// KeySchemeSecp256k1 is a single-key secp256k1 ECDSA key, e.g. a Secp256k1Account.
+	KeySchemeSecp256k1 KeyScheme = "secp256k1"
+	// KeySchemeMultiEd25519 is a K-of-N ED25519 account, e.g. a MultiEd25519Account.
+	KeySchemeMultiEd25519 KeyScheme = "multi_ed25519"
+	// KeySchemeMultiKey is a K-of-N account with keys of any scheme, e.g. a MultiKeyAccount.
+	KeySchemeMultiKey KeyScheme = "multi_key"
+)
+
+// Signer is implemented by anything that can sign Cedra transactions on behalf of an account,
+// such as an in-memory key (Account), a remote signing service or a hardware-backed key.
+type Signer interface {
+	// GetAccountAddress returns the 32-byte address of the account the signer signs for.
+	GetAccountAddress() [32]byte
+	// GetPublicKey returns the public key used to verify the signer's signatures.
+	GetPublicKey() []byte
+	// KeyScheme returns the signature scheme of the signer's key.
+	KeyScheme() KeyScheme
+	// Sign signs the message and returns the raw signature.
+	Sign(message []byte) ([]byte, error)
+	// NewAuthenticator creates the transaction authenticator for a signature produced by Sign.
+	NewAuthenticator(signature []byte) CedraAuthenticator
+}
+
+// ContextSigner is implemented by signers whose signing can be cancelled, such as RemoteSigner.
+// Transaction.Sign and the SignAs methods of fee payer and multi-agent transactions use SignContext
+// instead of Sign when the signer implements it.
+type ContextSigner interface {
+	Signer
+	// SignContext is like Sign, with a context cancelling the signing.
+	SignContext(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// SimulationSigner is implemented by signers providing the signature used to simulate their transactions.
+// The node rejects simulations with valid signatures but needs a signature with the layout of the signer's
+// scheme, e.g. one signature per required key of a multi-key account. Signers not implementing it are
+// simulated with a zeroed ED25519-sized signature.
+type SimulationSigner interface {
+	// SimulationSignature returns a zeroed signature with the layout of the signer's signatures.
+	SimulationSignature() []byte
+}
+
+// signMessage signs the message with the signer, using SignContext if the signer is a ContextSigner.
+func signMessage(ctx context.Context, signer Signer, message []byte) ([]byte, error) {
+	if contextSigner, ok := signer.(ContextSigner); ok {
+		return contextSigner.SignContext(ctx, message)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return signer.Sign(message)
+}
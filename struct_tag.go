@@ -1,23 +1,21 @@
 package cedra
 
 import (
-	"encoding/hex"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/cast"
 )
 
 const (
 	// structTagVariant is the variant identifier for struct tags.
 	structTagVariant = 7
-	// structTagTyArgsLen is the length of type arguments (currently always 0).
-	structTagTyArgsLen = 0
 	// tagSeparator is the separator used in struct tag strings (e.g., "address::module::name").
 	tagSeparator = "::"
 )
 
 // StructTag represents a type identifier in the Cedra blockchain.
-// It consists of an address, module name, and type name.
+// It consists of an address, module name, type name and optional generic type arguments.
 type StructTag struct {
 	// Address is the 32-byte address of the module.
 	Address [32]byte
@@ -25,41 +23,113 @@ type StructTag struct {
 	Module string
 	// Name is the name of the type.
 	Name string
+	// TypeArgs are the generic type arguments of the type, e.g. the coin type of 0x1::coin::CoinStore<T>.
+	TypeArgs []TypeTag
 }
 
-// NewStringStructTag parses a struct tag string in the format "address::module::name"
+// NewStringStructTag parses a struct tag string in the format "address::module::name", optionally
+// followed by generic type arguments (e.g. "0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>"),
 // and creates a StructTag instance. The address can optionally include the "0x" prefix.
 // Returns an error if the tag format is invalid.
 func NewStringStructTag(tag string) (StructTag, error) {
-	parts := strings.Split(tag, tagSeparator)
-	if len(parts) != 3 {
-		return StructTag{}, errors.New("can't create new struct tag: invalid struct tag")
-	}
-	bytes, err := hex.DecodeString(strings.TrimPrefix(parts[0], "0x"))
+	typeTag, err := ParseTypeTag(tag)
 	if err != nil {
-		return StructTag{}, errors.Wrap(err, "can't create new struct tag: invalid module address")
+		return StructTag{}, errors.Wrap(err, "can't create new struct tag")
+	}
+	structTag, ok := typeTag.(StructTag)
+	if !ok {
+		return StructTag{}, errors.Errorf("can't create new struct tag: %q is not a struct type", tag)
 	}
 
-	buf := [32]byte{}
-	copy((buf)[32-len(bytes):], bytes)
-
-	return StructTag{
-		Address: buf,
-		Module:  parts[1],
-		Name:    parts[2],
-	}, nil
+	return structTag, nil
 }
 
 // ToBCSBytes encodes the struct tag into Binary Canonical Serialization (BCS) format.
 // Returns the serialized byte representation of the struct tag.
-func (st *StructTag) ToBCSBytes() []byte {
+func (st StructTag) ToBCSBytes() []byte {
 	bcs := NewBCSEncoder()
 	defer bcs.buf.Reset()
 	bcs.EncodeEnum(structTagVariant)
 	bcs.WriteRawBytes(st.Address[:])
 	bcs.EncodeString(st.Module)
 	bcs.EncodeString(st.Name)
-	bcs.EncodeEnum(structTagTyArgsLen)
+	bcs.EncodeEnum(cast.ToUint64(len(st.TypeArgs)))
+	for _, typeArg := range st.TypeArgs {
+		bcs.WriteRawBytes(typeArg.ToBCSBytes())
+	}
 
 	return bcs.GetBytes()
 }
+
+// UnmarshalBCS decodes a struct tag encoded with ToBCSBytes.
+func (st *StructTag) UnmarshalBCS(bcs *BCSDecoder) error {
+	structTag, err := decodeStructTag(bcs)
+	if err != nil {
+		return err
+	}
+	*st = structTag
+
+	return nil
+}
+
+// String returns the canonical string form of the struct tag, e.g. "0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>".
+func (st StructTag) String() string {
+	var sb strings.Builder
+	sb.WriteString(addressToString(st.Address))
+	sb.WriteString(tagSeparator)
+	sb.WriteString(st.Module)
+	sb.WriteString(tagSeparator)
+	sb.WriteString(st.Name)
+	if len(st.TypeArgs) > 0 {
+		sb.WriteString("<")
+		for i, typeArg := range st.TypeArgs {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(typeArg.String())
+		}
+		sb.WriteString(">")
+	}
+
+	return sb.String()
+}
+
+// decodeStructTag decodes a struct tag previously encoded with StructTag.ToBCSBytes.
+func decodeStructTag(bcs *BCSDecoder) (StructTag, error) {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return StructTag{}, errors.Wrap(err, "can't decode struct tag variant")
+	}
+	if variant != structTagVariant {
+		return StructTag{}, errors.Errorf("can't decode struct tag: unexpected variant %d", variant)
+	}
+
+	return decodeStructTagBody(bcs, 0)
+}
+
+// decodeStructTagBody decodes the fields of a struct tag that follow its variant.
+func decodeStructTagBody(bcs *BCSDecoder, depth int) (StructTag, error) {
+	address, err := bcs.DecodeAddress()
+	if err != nil {
+		return StructTag{}, errors.Wrap(err, "can't decode struct tag address")
+	}
+	module, err := bcs.DecodeString()
+	if err != nil {
+		return StructTag{}, errors.Wrap(err, "can't decode struct tag module")
+	}
+	name, err := bcs.DecodeString()
+	if err != nil {
+		return StructTag{}, errors.Wrap(err, "can't decode struct tag name")
+	}
+	typeArgs, err := decodeTypeTags(bcs, depth)
+	if err != nil {
+		return StructTag{}, errors.Wrap(err, "can't decode struct tag")
+	}
+
+	return StructTag{
+		Address:  address,
+		Module:   module,
+		Name:     name,
+		TypeArgs: typeArgs,
+	}, nil
+}
@@ -1,8 +1,9 @@
 package cedra
 
 import (
-	"crypto/ed25519"
+	"context"
 	"crypto/sha3"
+	"encoding/hex"
 
 	"github.com/pkg/errors"
 )
@@ -10,6 +11,13 @@ import (
 const (
 	// transactionPrefix is the prefix used when signing transactions.
 	transactionPrefix = "CEDRA::RawTransaction"
+	// transactionWithDataPrefix is the prefix used when signing transactions with several signers:
+	// fee payer and multi-agent transactions.
+	transactionWithDataPrefix = "CEDRA::RawTransactionWithData"
+	// transactionHashPrefix is the prefix used when hashing committed transactions.
+	transactionHashPrefix = "CEDRA::Transaction"
+	// userTransactionVariant is the committed transaction variant identifier for user transactions.
+	userTransactionVariant = 0
 )
 
 type SequenceNumber uint64
@@ -22,6 +30,16 @@ func (s SequenceNumber) ToBCSBytes() []byte {
 	return EncodeUintToBCS(s.ToUint64())
 }
 
+func (s *SequenceNumber) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeUint64()
+	if err != nil {
+		return err
+	}
+	*s = SequenceNumber(value)
+
+	return nil
+}
+
 type MaxGasAmount uint64
 
 func (s MaxGasAmount) ToUint64() uint64 {
@@ -32,6 +50,16 @@ func (s MaxGasAmount) ToBCSBytes() []byte {
 	return EncodeUintToBCS(s.ToUint64())
 }
 
+func (s *MaxGasAmount) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeUint64()
+	if err != nil {
+		return err
+	}
+	*s = MaxGasAmount(value)
+
+	return nil
+}
+
 type GasUnitPrice uint64
 
 func (s GasUnitPrice) ToUint64() uint64 {
@@ -42,13 +70,28 @@ func (s GasUnitPrice) ToBCSBytes() []byte {
 	return EncodeUintToBCS(s.ToUint64())
 }
 
+func (s *GasUnitPrice) UnmarshalBCS(bcs *BCSDecoder) error {
+	value, err := bcs.DecodeUint64()
+	if err != nil {
+		return err
+	}
+	*s = GasUnitPrice(value)
+
+	return nil
+}
+
+// SimulatedGasMultiplier is a NewTransaction option that simulates the transaction and sets its MaxGasAmount
+// to the simulated gas used multiplied by the value, as a safety margin (e.g. 1.5).
+// The value must be at least 1, and the option can't be combined with MaxGasAmount.
+type SimulatedGasMultiplier float64
+
 // Transaction represents a complete Cedra blockchain transaction.
 // Fields are grouped by size for optimal memory alignment.
 type Transaction struct {
-	// Sender is the account that will sign and submit the transaction.
-	Sender Account
-	// Payload contains the transaction payload (module, function, arguments).
-	Payload TransactionPayload
+	// Sender is the signer of the account that will sign and submit the transaction.
+	Sender Signer
+	// Payload contains the transaction payload: an entry function call, a script or a multisig execution.
+	Payload Payload
 	// FaAddress is the struct tag for the fee asset (coin type).
 	FaAddress StructTag
 	// SequenceNumber is the sequence number for the sender account.
@@ -75,7 +118,8 @@ func (tx *Transaction) SetFeeCoin(coin string) error {
 func (tx *Transaction) ToBCSBytes() []byte {
 	bcs := NewBCSEncoder()
 	defer bcs.buf.Reset()
-	bcs.WriteRawBytes(tx.Sender.AccountAddress[:])
+	senderAddress := tx.Sender.GetAccountAddress()
+	bcs.WriteRawBytes(senderAddress[:])
 	bcs.WriteRawBytes(tx.SequenceNumber.ToBCSBytes())
 	bcs.WriteRawBytes(tx.Payload.ToBCSBytes())
 	bcs.WriteRawBytes(tx.MaxGasAmount.ToBCSBytes())
@@ -87,19 +131,149 @@ func (tx *Transaction) ToBCSBytes() []byte {
 	return bcs.GetBytes()
 }
 
-// Sign signs the transaction using the sender's private key and creates an authenticator.
-// Returns the encoded transaction bytes and the authenticator for submission.
-// The transaction is signed with the ED25519 private key after hashing with the transaction prefix.
-func (tx *Transaction) Sign() ([]byte, CedraAuthenticator) {
-	encodedTx := tx.ToBCSBytes()
-	txPrefix := sha3.Sum256([]byte(transactionPrefix))
+// UnmarshalBCS decodes a raw transaction encoded with ToBCSBytes. As with DecodeTransaction,
+// the Sender is an Account without keys.
+func (tx *Transaction) UnmarshalBCS(bcs *BCSDecoder) error {
+	decoded, err := decodeTransaction(bcs)
+	if err != nil {
+		return err
+	}
+	*tx = decoded
+
+	return nil
+}
+
+// SigningMessage returns the message that is signed to authorize the transaction:
+// the SHA3-256 hash of the transaction prefix followed by the encoded transaction.
+func (tx *Transaction) SigningMessage() []byte {
+	return newSigningMessage(transactionPrefix, tx.ToBCSBytes())
+}
+
+// newSigningMessage returns the SHA3-256 hash of the prefix followed by the encoded transaction.
+func newSigningMessage(prefix string, encodedTx []byte) []byte {
+	txPrefix := sha3.Sum256([]byte(prefix))
 
 	message := make([]byte, 0, len(txPrefix)+len(encodedTx))
 	message = append(message, txPrefix[:]...)
 	message = append(message, encodedTx...)
 
-	signature := ed25519.Sign(tx.Sender.PrivateKey, message)
-	authenticator := NewCedraAuthenticator(tx.Sender.PublicKey, signature)
+	return message
+}
+
+// Sign signs the transaction with the sender's signer and creates an authenticator.
+// Returns the encoded transaction bytes and the authenticator for submission.
+// The signer signs the encoded transaction prefixed with the hash of the transaction prefix.
+// The context cancels the signing if the sender is a ContextSigner.
+func (tx *Transaction) Sign(ctx context.Context) ([]byte, CedraAuthenticator, error) {
+	signature, err := signMessage(ctx, tx.Sender, tx.SigningMessage())
+	if err != nil {
+		return nil, CedraAuthenticator{}, errors.Wrap(err, "can't sign transaction")
+	}
+
+	return tx.ToBCSBytes(), tx.Sender.NewAuthenticator(signature), nil
+}
+
+// EncodeSignedTransaction combines the encoded raw transaction returned by Sign and its authenticator
+// into the signed transaction submitted to the network.
+func EncodeSignedTransaction(tx []byte, auth CedraAuthenticator) []byte {
+	authBytes := auth.EncodeBSC()
+	signedTx := make([]byte, 0, len(tx)+len(authBytes))
+	signedTx = append(signedTx, tx...)
+	signedTx = append(signedTx, authBytes...)
+
+	return signedTx
+}
+
+// TransactionHash computes the hash of a signed transaction as it will be committed on chain,
+// so that it can be recorded before submission. The tx and auth are the values returned by Sign.
+// Returns the "0x"-prefixed hexadecimal hash, in the format used by the node API.
+func TransactionHash(tx []byte, auth CedraAuthenticator) string {
+	return signedTransactionHash(EncodeSignedTransaction(tx, auth))
+}
+
+// signedTransactionHash computes the hash of a signed transaction encoded with EncodeSignedTransaction.
+func signedTransactionHash(signedTx []byte) string {
+	hashPrefix := sha3.Sum256([]byte(transactionHashPrefix))
+	hasher := sha3.New256()
+	hasher.Write(hashPrefix[:])
+	hasher.Write([]byte{userTransactionVariant})
+	hasher.Write(signedTx)
+
+	return keyPrefix + hex.EncodeToString(hasher.Sum(nil))
+}
+
+// DecodeTransaction decodes a raw transaction previously encoded with Transaction.ToBCSBytes.
+// Only the sender address is known from the encoded form, so the returned Sender is an Account without keys.
+// Returns an error if the input is malformed or contains trailing bytes.
+func DecodeTransaction(data []byte) (Transaction, error) {
+	bcs := NewBCSDecoder(data)
+	tx, err := decodeTransaction(bcs)
+	if err != nil {
+		return Transaction{}, err
+	}
+
+	return tx, bcs.Finish()
+}
+
+// DecodeSignedTransaction decodes a signed transaction, i.e. the raw transaction bytes
+// followed by the encoded authenticator, as submitted by CedraClient.SubmitTransaction.
+func DecodeSignedTransaction(data []byte) (Transaction, CedraAuthenticator, error) {
+	bcs := NewBCSDecoder(data)
+	tx, err := decodeTransaction(bcs)
+	if err != nil {
+		return Transaction{}, CedraAuthenticator{}, err
+	}
+	authenticator, err := decodeCedraAuthenticator(bcs)
+	if err != nil {
+		return Transaction{}, CedraAuthenticator{}, err
+	}
+
+	return tx, authenticator, bcs.Finish()
+}
+
+// decodeTransaction reads a single raw transaction from the decoder.
+func decodeTransaction(bcs *BCSDecoder) (Transaction, error) {
+	sender, err := bcs.DecodeAddress()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction sender")
+	}
+	seqNumber, err := bcs.DecodeUint64()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction sequence number")
+	}
+	payload, err := decodePayload(bcs)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction payload")
+	}
+	maxGasAmount, err := bcs.DecodeUint64()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction max gas amount")
+	}
+	gasUnitPrice, err := bcs.DecodeUint64()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction gas unit price")
+	}
+	expirationSeconds, err := bcs.DecodeUint64()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction expiration")
+	}
+	chainID, err := bcs.DecodeUint8()
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction chain id")
+	}
+	faAddress, err := decodeStructTag(bcs)
+	if err != nil {
+		return Transaction{}, errors.Wrap(err, "can't decode transaction fee asset")
+	}
 
-	return encodedTx, authenticator
+	return Transaction{
+		Sender:                     Account{AccountAddress: sender},
+		SequenceNumber:             SequenceNumber(seqNumber),
+		Payload:                    payload,
+		FaAddress:                  faAddress,
+		MaxGasAmount:               MaxGasAmount(maxGasAmount),
+		GasUnitPrice:               GasUnitPrice(gasUnitPrice),
+		ExpirationTimestampSeconds: expirationSeconds,
+		ChainId:                    chainID,
+	}, nil
 }
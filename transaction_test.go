@@ -0,0 +1,65 @@
+package cedra
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+)
+
+const (
+	// testSignedTransaction is the test transaction signed by the test account: the raw transaction
+	// followed by the ED25519 authenticator of the account public key and signature.
+	testSignedTransaction = "" +
+		// Sender, sequence number 7.
+		"3c9124028c90111d7cfd47a28fae30612e397d115c7b78f69713fb729347a77e" + "0700000000000000" +
+		// Entry function 0x1::cedra_account::transfer.
+		"02" + "0000000000000000000000000000000000000000000000000000000000000001" +
+		"0d63656472615f6163636f756e74" + "087472616e73666572" +
+		// Type arguments 0x1::cedra_coin::CedraCoin and vector<u8>.
+		"02" + "07" + "0000000000000000000000000000000000000000000000000000000000000001" +
+		"0a63656472615f636f696e" + "094365647261436f696e" + "00" + "0601" +
+		// Arguments.
+		"02" + "020102" + "080500000000000000" +
+		// Max gas amount, gas unit price, expiration timestamp and chain id.
+		"e803000000000000" + "6400000000000000" + "7b00000000000000" + "02" +
+		// Fee asset 0x1::cedra_coin::CedraCoin.
+		"07" + "0000000000000000000000000000000000000000000000000000000000000001" +
+		"0a63656472615f636f696e" + "094365647261436f696e" + "00" +
+		// ED25519 authenticator: public key and signature.
+		"00" + "20" + "f55da5f124ff9aa25765657fd93346b3a9876387ddb0e64e71f2bc9d85fede63" +
+		"40" + "0f256554d76025c4b967f8359e5e15b11331dbc5cfe044a20a339e2b0adec319" +
+		"d3f672ab2a5a2497f4f50ef59423f54526c01f7a35e4e31c86676af4aa0a8c00"
+	// testTransactionHash is the committed hash of testSignedTransaction.
+	testTransactionHash = "0x3cbb3a0f46925c5c5284e626863673671f9b3968f9e57800c952e53c5c93b760"
+)
+
+func TestSignedTransactionBCS(t *testing.T) {
+	tx := newTestTransaction(t)
+	encoded, auth, err := tx.Sign(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := mustDecodeHex(t, testSignedTransaction)
+	if got := EncodeSignedTransaction(encoded, auth); !bytes.Equal(got, want) {
+		t.Fatalf("EncodeSignedTransaction() = %s, want %s", hex.EncodeToString(got), testSignedTransaction)
+	}
+	if got := TransactionHash(encoded, auth); got != testTransactionHash {
+		t.Fatalf("TransactionHash() = %s, want %s", got, testTransactionHash)
+	}
+	if got := signedTransactionHash(want); got != testTransactionHash {
+		t.Fatalf("signedTransactionHash() = %s, want %s", got, testTransactionHash)
+	}
+}
+
+func TestTransactionSigningMessage(t *testing.T) {
+	tx := newTestTransaction(t)
+
+	// SHA3-256 of "CEDRA::RawTransaction" followed by the raw transaction.
+	want := mustDecodeHex(t, "028b8f5d3262a1882c1b02695dc46cad843f449e67320a73fc70a878ac15ddc1")
+	want = append(want, tx.ToBCSBytes()...)
+	if got := tx.SigningMessage(); !bytes.Equal(got, want) {
+		t.Fatalf("SigningMessage() = %x, want %x", got, want)
+	}
+}
@@ -0,0 +1,390 @@
+package cedra
+
+import (
+	"encoding/hex"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// vectorTagVariant is the variant identifier for vector type tags.
+	vectorTagVariant = 6
+	// maxTypeTagDepth limits the nesting of type tags accepted by the parser and the decoder.
+	maxTypeTagDepth = 16
+)
+
+// TypeTag represents a Move type in the Cedra blockchain, such as u64, vector<u8> or
+// 0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>.
+// It is implemented by PrimitiveTypeTag, VectorTag and StructTag.
+type TypeTag interface {
+	// ToBCSBytes encodes the type tag, including its variant, into BCS format.
+	ToBCSBytes() []byte
+	// String returns the canonical string form of the type tag.
+	String() string
+}
+
+// PrimitiveTypeTag is a type tag without parameters. Its value is the BCS variant of the type.
+type PrimitiveTypeTag uint64
+
+const (
+	// BoolTag is the type tag of the Move bool type.
+	BoolTag PrimitiveTypeTag = 0
+	// U8Tag is the type tag of the Move u8 type.
+	U8Tag PrimitiveTypeTag = 1
+	// U64Tag is the type tag of the Move u64 type.
+	U64Tag PrimitiveTypeTag = 2
+	// U128Tag is the type tag of the Move u128 type.
+	U128Tag PrimitiveTypeTag = 3
+	// AddressTag is the type tag of the Move address type.
+	AddressTag PrimitiveTypeTag = 4
+	// SignerTag is the type tag of the Move signer type.
+	SignerTag PrimitiveTypeTag = 5
+	// U16Tag is the type tag of the Move u16 type.
+	U16Tag PrimitiveTypeTag = 8
+	// U32Tag is the type tag of the Move u32 type.
+	U32Tag PrimitiveTypeTag = 9
+	// U256Tag is the type tag of the Move u256 type.
+	U256Tag PrimitiveTypeTag = 10
+)
+
+// primitiveTypeTags maps the canonical names of primitive types to their type tags.
+var primitiveTypeTags = map[string]PrimitiveTypeTag{
+	"bool":    BoolTag,
+	"u8":      U8Tag,
+	"u16":     U16Tag,
+	"u32":     U32Tag,
+	"u64":     U64Tag,
+	"u128":    U128Tag,
+	"u256":    U256Tag,
+	"address": AddressTag,
+	"signer":  SignerTag,
+}
+
+// ToBCSBytes encodes the type tag into Binary Canonical Serialization (BCS) format.
+func (t PrimitiveTypeTag) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(uint64(t))
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes a primitive type tag encoded with ToBCSBytes.
+func (t *PrimitiveTypeTag) UnmarshalBCS(bcs *BCSDecoder) error {
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return errors.Wrap(err, "can't decode type tag variant")
+	}
+	tag := PrimitiveTypeTag(variant)
+	if !tag.isValid() {
+		return errors.Errorf("can't decode primitive type tag: unsupported variant %d", variant)
+	}
+	*t = tag
+
+	return nil
+}
+
+// String returns the canonical name of the primitive type.
+func (t PrimitiveTypeTag) String() string {
+	for name, tag := range primitiveTypeTags {
+		if tag == t {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+// isValid reports whether the value is one of the known primitive type tags.
+func (t PrimitiveTypeTag) isValid() bool {
+	_, ok := primitiveTypeTags[t.String()]
+
+	return ok
+}
+
+// VectorTag is the type tag of a Move vector<T>.
+type VectorTag struct {
+	// ElementType is the type of the vector elements.
+	ElementType TypeTag
+}
+
+// ToBCSBytes encodes the type tag into Binary Canonical Serialization (BCS) format.
+func (t VectorTag) ToBCSBytes() []byte {
+	bcs := NewBCSEncoder()
+	defer bcs.buf.Reset()
+	bcs.EncodeEnum(vectorTagVariant)
+	bcs.WriteRawBytes(t.ElementType.ToBCSBytes())
+
+	return bcs.GetBytes()
+}
+
+// UnmarshalBCS decodes a vector type tag encoded with ToBCSBytes.
+func (t *VectorTag) UnmarshalBCS(bcs *BCSDecoder) error {
+	if err := bcs.decodeVariant(vectorTagVariant, "vector type tag"); err != nil {
+		return err
+	}
+	elementType, err := decodeTypeTag(bcs, 1)
+	if err != nil {
+		return err
+	}
+	t.ElementType = elementType
+
+	return nil
+}
+
+// String returns the canonical string form of the vector type.
+func (t VectorTag) String() string {
+	return "vector<" + t.ElementType.String() + ">"
+}
+
+// ParseTypeTag parses the canonical string form of a Move type, e.g. "u64", "vector<u8>" or
+// "0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>". Addresses may be short or full length,
+// with or without the "0x" prefix. Returns an error if the string isn't a valid type.
+func ParseTypeTag(tag string) (TypeTag, error) {
+	parser := typeTagParser{input: tag}
+	typeTag, err := parser.parseTypeTag(0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't parse type tag %q", tag)
+	}
+	parser.skipSpaces()
+	if parser.pos != len(parser.input) {
+		return nil, errors.Errorf("can't parse type tag %q: unexpected %q at position %d", tag, parser.input[parser.pos:], parser.pos)
+	}
+
+	return typeTag, nil
+}
+
+// typeTagParser is a recursive descent parser for the canonical string form of type tags.
+type typeTagParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+// consume skips spaces and consumes the token if it is next in the input.
+func (p *typeTagParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *typeTagParser) expect(token string) error {
+	if !p.consume(token) {
+		return errors.Errorf("expected %q at position %d", token, p.pos)
+	}
+
+	return nil
+}
+
+// word reads the next identifier or address.
+func (p *typeTagParser) word() (string, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := rune(p.input[p.pos])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		p.pos++
+	}
+	if start == p.pos {
+		return "", errors.Errorf("expected identifier at position %d", start)
+	}
+
+	return p.input[start:p.pos], nil
+}
+
+func (p *typeTagParser) identifier() (string, error) {
+	start := p.pos
+	word, err := p.word()
+	if err != nil {
+		return "", err
+	}
+	if !isMoveIdentifier(word) {
+		return "", errors.Errorf("invalid identifier %q at position %d", word, start)
+	}
+
+	return word, nil
+}
+
+func (p *typeTagParser) parseTypeTag(depth int) (TypeTag, error) {
+	if depth > maxTypeTagDepth {
+		return nil, errors.New("type tag is nested too deeply")
+	}
+	word, err := p.word()
+	if err != nil {
+		return nil, err
+	}
+
+	if tag, ok := primitiveTypeTags[word]; ok {
+		return tag, nil
+	}
+	if word == "vector" {
+		if err := p.expect("<"); err != nil {
+			return nil, err
+		}
+		elementType, err := p.parseTypeTag(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+
+		return VectorTag{ElementType: elementType}, nil
+	}
+
+	address, err := parseTagAddress(word)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tagSeparator); err != nil {
+		return nil, err
+	}
+	module, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(tagSeparator); err != nil {
+		return nil, err
+	}
+	name, err := p.identifier()
+	if err != nil {
+		return nil, err
+	}
+
+	structTag := StructTag{
+		Address: address,
+		Module:  module,
+		Name:    name,
+	}
+	if !p.consume("<") {
+		return structTag, nil
+	}
+	for {
+		typeArg, err := p.parseTypeTag(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		structTag.TypeArgs = append(structTag.TypeArgs, typeArg)
+		if p.consume(">") {
+			return structTag, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// parseTagAddress parses the address part of a struct tag.
+func parseTagAddress(address string) ([32]byte, error) {
+	address = strings.TrimPrefix(address, keyPrefix)
+	if len(address)%2 == 1 {
+		address = "0" + address
+	}
+	bytes, err := hex.DecodeString(address)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "invalid module address")
+	}
+	if len(bytes) == 0 || len(bytes) > 32 {
+		return [32]byte{}, errors.New("invalid module address length")
+	}
+
+	buf := [32]byte{}
+	copy(buf[32-len(bytes):], bytes)
+
+	return buf, nil
+}
+
+// isMoveIdentifier reports whether the string is a valid Move identifier.
+func isMoveIdentifier(value string) bool {
+	if value == "" || value == "_" {
+		return false
+	}
+	for i, r := range value {
+		switch {
+		case r == '_', r < unicode.MaxASCII && unicode.IsLetter(r):
+		case i > 0 && r < unicode.MaxASCII && unicode.IsDigit(r):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// addressToString returns the canonical string form of an address: special addresses (0x0 to 0xf)
+// are written in short form, all other addresses in full 64 hex digit form.
+func addressToString(address [32]byte) string {
+	isSpecial := address[31] < 0x10
+	for _, b := range address[:31] {
+		if b != 0 {
+			isSpecial = false
+
+			break
+		}
+	}
+	if isSpecial {
+		return keyPrefix + strings.TrimPrefix(hex.EncodeToString(address[31:]), "0")
+	}
+
+	return keyPrefix + hex.EncodeToString(address[:])
+}
+
+// decodeTypeTag reads a single type tag, including its variant, from the decoder.
+func decodeTypeTag(bcs *BCSDecoder, depth int) (TypeTag, error) {
+	if depth > maxTypeTagDepth {
+		return nil, errors.New("can't decode type tag: type tag is nested too deeply")
+	}
+	variant, err := bcs.DecodeEnum()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode type tag variant")
+	}
+
+	switch variant {
+	case vectorTagVariant:
+		elementType, err := decodeTypeTag(bcs, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return VectorTag{ElementType: elementType}, nil
+	case structTagVariant:
+		return decodeStructTagBody(bcs, depth)
+	}
+	if tag := PrimitiveTypeTag(variant); tag.isValid() {
+		return tag, nil
+	}
+
+	return nil, errors.Errorf("can't decode type tag: unsupported variant %d", variant)
+}
+
+// decodeTypeTags reads a ULEB128-prefixed list of type tags from the decoder.
+func decodeTypeTags(bcs *BCSDecoder, depth int) ([]TypeTag, error) {
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode type arguments length")
+	}
+	typeTags := make([]TypeTag, 0, length)
+	for i := 0; i < length; i++ {
+		typeTag, err := decodeTypeTag(bcs, depth+1)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't decode type argument %d", i)
+		}
+		typeTags = append(typeTags, typeTag)
+	}
+
+	return typeTags, nil
+}
@@ -0,0 +1,141 @@
+package cedra
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseTypeTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "primitive", input: "u64", want: "u64"},
+		{name: "vector", input: "vector<u8>", want: "vector<u8>"},
+		{name: "struct", input: "0x1::cedra_coin::CedraCoin", want: "0x1::cedra_coin::CedraCoin"},
+		{name: "generic struct", input: "0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>", want: "0x1::coin::CoinStore<0x1::cedra_coin::CedraCoin>"},
+		{name: "nested option", input: "vector<0x1::option::Option<u64>>", want: "vector<0x1::option::Option<u64>>"},
+		{name: "several type arguments", input: "0x1::pair::Pair<u8,vector<address>>", want: "0x1::pair::Pair<u8, vector<address>>"},
+		{name: "spaces", input: " vector < 0x1::option::Option< u64 > > ", want: "vector<0x1::option::Option<u64>>"},
+		{name: "padded special address", input: "0x0001::option::Option<bool>", want: "0x1::option::Option<bool>"},
+		{name: "address without prefix", input: "1::option::Option<u8>", want: "0x1::option::Option<u8>"},
+		{
+			name:  "short non-special address",
+			input: "0xabc::token::Token",
+			want:  "0x" + strings.Repeat("0", 61) + "abc::token::Token",
+		},
+		{name: "maximum depth", input: strings.Repeat("vector<", maxTypeTagDepth) + "u8" + strings.Repeat(">", maxTypeTagDepth), want: strings.Repeat("vector<", maxTypeTagDepth) + "u8" + strings.Repeat(">", maxTypeTagDepth)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, err := ParseTypeTag(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tag.String() != tt.want {
+				t.Fatalf("ParseTypeTag(%q).String() = %q, want %q", tt.input, tag.String(), tt.want)
+			}
+
+			reparsed, err := ParseTypeTag(tag.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(reparsed.ToBCSBytes(), tag.ToBCSBytes()) {
+				t.Fatalf("reparsed %q encodes to %x, want %x", tag.String(), reparsed.ToBCSBytes(), tag.ToBCSBytes())
+			}
+
+			bcs := NewBCSDecoder(tag.ToBCSBytes())
+			decoded, err := decodeTypeTag(bcs, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := bcs.Finish(); err != nil {
+				t.Fatal(err)
+			}
+			if decoded.String() != tt.want {
+				t.Fatalf("decoded type tag = %q, want %q", decoded.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTypeTagRejectsInvalidInput(t *testing.T) {
+	tooDeep := strings.Repeat("vector<", maxTypeTagDepth+1) + "u8" + strings.Repeat(">", maxTypeTagDepth+1)
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "unknown primitive", input: "u63"},
+		{name: "identifier starting with a digit", input: "0x1::coin::1Coin"},
+		{name: "underscore identifier", input: "0x1::_::Coin"},
+		{name: "non-ascii identifier", input: "0x1::café::Coin"},
+		{name: "invalid address", input: "0xzz::coin::Coin"},
+		{name: "address too long", input: "0x" + strings.Repeat("1", 65) + "::coin::Coin"},
+		{name: "missing name", input: "0x1::coin"},
+		{name: "empty vector", input: "vector<>"},
+		{name: "unclosed type arguments", input: "0x1::option::Option<u64"},
+		{name: "trailing comma", input: "0x1::option::Option<u64,>"},
+		{name: "trailing input", input: "u64 u8"},
+		{name: "trailing bracket", input: "vector<u8>>"},
+		{name: "nested too deeply", input: tooDeep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tag, err := ParseTypeTag(tt.input); err == nil {
+				t.Fatalf("ParseTypeTag(%q) = %q, want an error", tt.input, tag)
+			}
+		})
+	}
+}
+
+func TestTypeTagBCS(t *testing.T) {
+	tag, err := ParseTypeTag("vector<0x1::option::Option<u64>>")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// vector variant, struct variant, address, module, name, one type argument of variant u64.
+	want := []byte{0x06, 0x07}
+	want = append(want, cedraFrameworkAddress[:]...)
+	want = append(want, 0x06, 'o', 'p', 't', 'i', 'o', 'n')
+	want = append(want, 0x06, 'O', 'p', 't', 'i', 'o', 'n')
+	want = append(want, 0x01, 0x02)
+	if !bytes.Equal(tag.ToBCSBytes(), want) {
+		t.Fatalf("ToBCSBytes() = %x, want %x", tag.ToBCSBytes(), want)
+	}
+
+	var decoded TypeTag
+	if err := Unmarshal(want, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.String() != tag.String() {
+		t.Fatalf("Unmarshal() = %q, want %q", decoded.String(), tag.String())
+	}
+}
+
+func TestDecodeTypeTagRejectsInvalidInput(t *testing.T) {
+	tooDeep := bytes.Repeat([]byte{0x06}, maxTypeTagDepth+1)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "unknown variant", data: []byte{0x0b}},
+		{name: "unfinished vector", data: []byte{0x06}},
+		{name: "truncated struct", data: []byte{0x07, 0x00}},
+		{name: "nested too deeply", data: append(tooDeep, 0x01)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeTypeTag(NewBCSDecoder(tt.data), 0); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package cedra
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ViewValues are the BCS-encoded return values of a view function called with CedraClient.View,
+// in the order of the function return types. Use DecodeViewValue to decode them.
+type ViewValues [][]byte
+
+// ViewJSONValues are the JSON return values of a view function called with CedraClient.ViewJSON,
+// in the order of the function return types. Use DecodeViewJSONValue to decode them.
+type ViewJSONValues []json.RawMessage
+
+// viewRequest is the JSON request of a view function call.
+type viewRequest struct {
+	// Function is the fully qualified name of the view function, e.g. "0x1::coin::balance".
+	Function string `json:"function"`
+	// TypeArguments are the canonical string forms of the type arguments.
+	TypeArguments []string `json:"type_arguments"`
+	// Arguments are the JSON values of the function arguments.
+	Arguments []any `json:"arguments"`
+}
+
+// View calls the read-only view function (e.g. "0x1::coin::balance") with BCS-encoded arguments,
+// built as for TransactionPayload.Arguments, and returns its BCS-encoded return values.
+// Returns an error if the function name is invalid or if the call fails, e.g. when the function aborts.
+func (c CedraClient) View(ctx context.Context, function string, typeArgs []TypeTag, args [][]byte) (ViewValues, error) {
+	payload, err := newViewPayload(function, typeArgs, args)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't call view function")
+	}
+	bcs := NewBCSEncoder()
+	payload.encodeEntryFunction(bcs)
+
+	response, err := c.nodes.ViewBCS(ctx, bcs.GetBytes())
+	if err != nil {
+		return nil, err
+	}
+	values, err := decodeViewValues(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't decode view function return values")
+	}
+
+	return values, nil
+}
+
+// ViewJSON calls the read-only view function (e.g. "0x1::coin::balance") with JSON arguments and returns
+// its JSON return values. Arguments follow the node API conventions: addresses and u64, u128 and u256
+// values are strings, and vector<u8> values are "0x"-prefixed hexadecimal strings.
+// Returns an error if the function name is invalid or if the call fails, e.g. when the function aborts.
+func (c CedraClient) ViewJSON(ctx context.Context, function string, typeArgs []TypeTag, args []any) (ViewJSONValues, error) {
+	if _, err := newViewPayload(function, typeArgs, nil); err != nil {
+		return nil, errors.Wrap(err, "can't call view function")
+	}
+	request := viewRequest{
+		Function:      function,
+		TypeArguments: typeTagStrings(typeArgs),
+		Arguments:     args,
+	}
+	if request.Arguments == nil {
+		request.Arguments = []any{}
+	}
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't marshal view function request")
+	}
+
+	values, err := c.nodes.ViewJSON(ctx, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// DecodeViewValue decodes the BCS-encoded return value at the index into a Go value, following the rules
+// of Unmarshal: e.g. uint64 for u64, bool, [32]byte for address, string for 0x1::string::String,
+// or a pointer for 0x1::option::Option.
+// Returns an error if there is no value at the index or if it can't be decoded into the type.
+func DecodeViewValue[T any](values ViewValues, index int) (T, error) {
+	var value T
+	if index < 0 || index >= len(values) {
+		return value, errors.Errorf("can't decode view value %d: function returned %d values", index, len(values))
+	}
+	if err := Unmarshal(values[index], &value); err != nil {
+		return value, errors.Wrapf(err, "can't decode view value %d", index)
+	}
+
+	return value, nil
+}
+
+// DecodeViewJSONValue decodes the JSON return value at the index into a Go value.
+// Integers returned as strings by the node, such as u64 values, can be decoded into Go integers or *big.Int.
+// Returns an error if there is no value at the index or if it can't be decoded into the type.
+func DecodeViewJSONValue[T any](values ViewJSONValues, index int) (T, error) {
+	var value T
+	if index < 0 || index >= len(values) {
+		return value, errors.Errorf("can't decode view value %d: function returned %d values", index, len(values))
+	}
+	err := json.Unmarshal(values[index], &value)
+	if err == nil {
+		return value, nil
+	}
+
+	// u64, u128 and u256 values are returned as strings.
+	var quoted string
+	if json.Unmarshal(values[index], &quoted) == nil && json.Unmarshal([]byte(quoted), &value) == nil {
+		return value, nil
+	}
+
+	return value, errors.Wrapf(err, "can't decode view value %d", index)
+}
+
+// newViewPayload creates the entry function call of the view function in the "address::module::function" format.
+func newViewPayload(function string, typeArgs []TypeTag, args [][]byte) (*TransactionPayload, error) {
+	parts := strings.Split(function, "::")
+	if len(parts) != 3 || !isMoveIdentifier(parts[1]) || !isMoveIdentifier(parts[2]) {
+		return nil, errors.Errorf("invalid function %q: expected address::module::function", function)
+	}
+	moduleAddress, err := parseTagAddress(parts[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid function %q", function)
+	}
+
+	return &TransactionPayload{
+		ModuleAddress: moduleAddress,
+		ModuleName:    parts[1],
+		FunctionName:  parts[2],
+		TypeArguments: typeArgs,
+		Arguments:     args,
+	}, nil
+}
+
+// decodeViewValues decodes the BCS response of a view function: a vector of BCS-encoded return values.
+func decodeViewValues(data []byte) (ViewValues, error) {
+	bcs := NewBCSDecoder(data)
+	length, err := bcs.DecodeLength()
+	if err != nil {
+		return nil, err
+	}
+	values := make(ViewValues, 0, length)
+	for range length {
+		value, err := bcs.DecodeBytes()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, bcs.Finish()
+}
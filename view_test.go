@@ -0,0 +1,145 @@
+package cedra
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testViewResponse is a BCS view function response, a vector<vector<u8>> of the BCS-encoded return values
+// (u64, address, String, Option<u64>, Option<address>) = (1000000, @0x1, "CED", option::some(5), option::none()).
+var testViewResponse = "05" +
+	"08" + "40420f0000000000" +
+	"20" + strings.Repeat("00", 31) + "01" +
+	"04" + "03" + "434544" +
+	"09" + "01" + "0500000000000000" +
+	"01" + "00"
+
+// decodeTestViewResponse returns the values of testViewResponse.
+func decodeTestViewResponse(t *testing.T) ViewValues {
+	t.Helper()
+	data, err := hex.DecodeString(testViewResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := decodeViewValues(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return values
+}
+
+func TestDecodeViewValues(t *testing.T) {
+	values := decodeTestViewResponse(t)
+	if len(values) != 5 {
+		t.Fatalf("decodeViewValues() returned %d values, want 5", len(values))
+	}
+
+	amount, err := DecodeViewValue[uint64](values, 0)
+	if err != nil || amount != 1000000 {
+		t.Fatalf("DecodeViewValue[uint64]() = %d, %v, want 1000000", amount, err)
+	}
+	address, err := DecodeViewValue[[32]byte](values, 1)
+	if err != nil || address != cedraFrameworkAddress {
+		t.Fatalf("DecodeViewValue[[32]byte]() = %x, %v, want 0x1", address, err)
+	}
+	symbol, err := DecodeViewValue[string](values, 2)
+	if err != nil || symbol != "CED" {
+		t.Fatalf("DecodeViewValue[string]() = %q, %v, want CED", symbol, err)
+	}
+	some, err := DecodeViewValue[*uint64](values, 3)
+	if err != nil || some == nil || *some != 5 {
+		t.Fatalf("DecodeViewValue[*uint64]() = %v, %v, want 5", some, err)
+	}
+	none, err := DecodeViewValue[*[32]byte](values, 4)
+	if err != nil || none != nil {
+		t.Fatalf("DecodeViewValue[*[32]byte]() = %v, %v, want nil", none, err)
+	}
+}
+
+func TestDecodeViewValueErrors(t *testing.T) {
+	values := decodeTestViewResponse(t)
+
+	if _, err := DecodeViewValue[uint64](values, 5); err == nil {
+		t.Fatal("expected an error for an index out of range")
+	}
+	if _, err := DecodeViewValue[uint64](values, -1); err == nil {
+		t.Fatal("expected an error for a negative index")
+	}
+	if _, err := DecodeViewValue[uint64](values, 2); err == nil {
+		t.Fatal("expected an error for a String decoded as u64")
+	}
+	if _, err := DecodeViewValue[uint8](values, 0); err == nil {
+		t.Fatal("expected an error for a u64 decoded as u8")
+	}
+}
+
+func TestDecodeViewValuesRejectsMalformedResponse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "empty", data: ""},
+		{name: "missing value", data: "02" + "0100"},
+		{name: "truncated value", data: "01" + "08" + "40420f"},
+		{name: "trailing bytes", data: "01" + "0100" + "00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := hex.DecodeString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := decodeViewValues(data); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestCedraClientView(t *testing.T) {
+	response, err := hex.DecodeString(testViewResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/view" {
+			http.NotFound(w, r)
+
+			return
+		}
+		if r.Header.Get("content-type") != contentTypeViewFunctionBcs {
+			t.Errorf("content-type = %q, want %q", r.Header.Get("content-type"), contentTypeViewFunctionBcs)
+		}
+		request, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		// The request starts with the module address of the function.
+		if len(request) < 32 || [32]byte(request[:32]) != cedraFrameworkAddress {
+			t.Errorf("request = %x, want the 0x1 module address first", request)
+		}
+		w.Header().Set("content-type", contentTypeBcs)
+		_, _ = w.Write(response)
+	}))
+	defer server.Close()
+
+	client, err := NewCedraClient(TestnetChainID, WithNodeURL(server.URL+"/v1/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values, err := client.View(context.Background(), "0x1::coin::balance", nil, [][]byte{cedraFrameworkAddress[:]})
+	if err != nil {
+		t.Fatalf("View() error = %v", err)
+	}
+	balance, err := DecodeViewValue[uint64](values, 0)
+	if err != nil || balance != 1000000 {
+		t.Fatalf("DecodeViewValue[uint64]() = %d, %v, want 1000000", balance, err)
+	}
+}
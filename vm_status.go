@@ -0,0 +1,172 @@
+package cedra
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Errors matched by the errors returned by ParseVMStatus, to be checked with errors.Is.
+var (
+	// ErrOutOfGas means that the transaction ran out of gas before completing.
+	ErrOutOfGas = errors.New("out of gas")
+	// ErrSequenceNumberTooOld means that the transaction sequence number was already used.
+	ErrSequenceNumberTooOld = errors.New("sequence number too old")
+	// ErrSequenceNumberTooNew means that the transaction sequence number is ahead of the account sequence number.
+	ErrSequenceNumberTooNew = errors.New("sequence number too new")
+	// ErrTransactionExpired means that the transaction expiration timestamp has passed.
+	ErrTransactionExpired = errors.New("transaction expired")
+	// ErrInsufficientBalance means that the account can't pay the transaction fee or the transferred amount.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+)
+
+// vmStatusErrors maps VM status codes and messages to the errors they match.
+var vmStatusErrors = []struct {
+	status string
+	err    error
+}{
+	{"OUT_OF_GAS", ErrOutOfGas},
+	{"Out of gas", ErrOutOfGas},
+	{"SEQUENCE_NUMBER_TOO_OLD", ErrSequenceNumberTooOld},
+	{"SEQUENCE_NUMBER_TOO_NEW", ErrSequenceNumberTooNew},
+	{"TRANSACTION_EXPIRED", ErrTransactionExpired},
+	{"INSUFFICIENT_BALANCE_FOR_TRANSACTION_FEE", ErrInsufficientBalance},
+}
+
+var (
+	// moveAbortPattern matches "Move abort in 0x1::coin: EINSUFFICIENT_BALANCE(0x10006): description"
+	// and "Move abort in 0x1::coin: 0x10006" VM statuses.
+	moveAbortPattern = regexp.MustCompile(`^Move abort in (\S+): (?:(\w+)\((0x[0-9a-fA-F]+)\)(?:: (.*))?|(0x[0-9a-fA-F]+))$`)
+	// executionFailurePattern matches "Execution failed in 0x1::module::function at code offset 12" VM statuses.
+	executionFailurePattern = regexp.MustCompile(`^Execution failed in (\S+) at code offset (\d+)$`)
+)
+
+// VMStatusError is the error of a transaction whose execution failed with the VM status.
+// It matches ErrOutOfGas, ErrSequenceNumberTooOld, ErrSequenceNumberTooNew, ErrTransactionExpired
+// or ErrInsufficientBalance with errors.Is when the status is one of them.
+type VMStatusError struct {
+	// VMStatus is the virtual machine status of the transaction.
+	VMStatus string
+	// Err is the known error matching the status, if any.
+	Err error
+}
+
+// Error implements error.
+func (e *VMStatusError) Error() string {
+	return "transaction failed: " + e.VMStatus
+}
+
+// Unwrap returns the known error matching the status, if any.
+func (e *VMStatusError) Unwrap() error {
+	return e.Err
+}
+
+// MoveAbortError is the error of a transaction aborted by a Move abort.
+// It matches ErrInsufficientBalance with errors.Is when the abort reason is EINSUFFICIENT_BALANCE.
+type MoveAbortError struct {
+	// VMStatus is the virtual machine status of the transaction.
+	VMStatus string
+	// Location is the module that aborted (e.g. "0x1::coin"), or "script".
+	Location string
+	// Code is the abort code.
+	Code uint64
+	// Reason is the name of the abort code constant (e.g. "EINSUFFICIENT_BALANCE"), if known.
+	Reason string
+	// Description is the human-readable description of the abort code, if known.
+	Description string
+	// ExplainErr is the error that prevented resolving the Reason and Description from the module
+	// error map, e.g. a failed request or a cancelled context, if any.
+	ExplainErr error
+}
+
+// Error implements error.
+func (e *MoveAbortError) Error() string {
+	if e.Reason == "" || strings.Contains(e.VMStatus, e.Reason) {
+		return "transaction aborted: " + e.VMStatus
+	}
+	if e.Description == "" {
+		return "transaction aborted: " + e.VMStatus + " (" + e.Reason + ")"
+	}
+
+	return "transaction aborted: " + e.VMStatus + " (" + e.Reason + ": " + e.Description + ")"
+}
+
+// Is reports whether the abort matches the target error.
+func (e *MoveAbortError) Is(target error) bool {
+	return target == ErrInsufficientBalance && e.Reason == "EINSUFFICIENT_BALANCE"
+}
+
+// Category returns the error category of the abort code as defined by 0x1::error
+// (e.g. 1 for INVALID_ARGUMENT or 6 for NOT_FOUND).
+func (e *MoveAbortError) Category() uint64 {
+	return e.Code >> 16
+}
+
+// ReasonCode returns the reason of the abort code in its module, i.e. the code without its category.
+func (e *MoveAbortError) ReasonCode() uint64 {
+	return e.Code & 0xFFFF
+}
+
+// ExecutionFailureError is the error of a transaction whose execution failed with a runtime error,
+// such as an arithmetic overflow.
+type ExecutionFailureError struct {
+	// VMStatus is the virtual machine status of the transaction.
+	VMStatus string
+	// Location is the function in which the execution failed (e.g. "0x1::coin::transfer").
+	Location string
+	// CodeOffset is the offset of the failing instruction in the function bytecode.
+	CodeOffset uint64
+}
+
+// Error implements error.
+func (e *ExecutionFailureError) Error() string {
+	return "transaction execution failed: " + e.VMStatus
+}
+
+// ParseVMStatus parses the virtual machine status of a transaction into a typed error:
+// a *MoveAbortError, an *ExecutionFailureError or a *VMStatusError.
+// Returns nil if the transaction was executed successfully.
+func ParseVMStatus(vmStatus string) error {
+	if vmStatus == executedTx {
+		return nil
+	}
+
+	if match := moveAbortPattern.FindStringSubmatch(vmStatus); match != nil {
+		abort := &MoveAbortError{
+			VMStatus:    vmStatus,
+			Location:    match[1],
+			Reason:      match[2],
+			Description: match[4],
+		}
+		code := match[3]
+		if code == "" {
+			code = match[5]
+		}
+		abort.Code, _ = strconv.ParseUint(strings.TrimPrefix(code, keyPrefix), 16, 64)
+
+		return abort
+	}
+
+	if match := executionFailurePattern.FindStringSubmatch(vmStatus); match != nil {
+		offset, _ := strconv.ParseUint(match[2], 10, 64)
+
+		return &ExecutionFailureError{
+			VMStatus:   vmStatus,
+			Location:   match[1],
+			CodeOffset: offset,
+		}
+	}
+
+	statusErr := &VMStatusError{VMStatus: vmStatus}
+	for _, known := range vmStatusErrors {
+		if strings.Contains(vmStatus, known.status) {
+			statusErr.Err = known.err
+
+			break
+		}
+	}
+
+	return statusErr
+}
@@ -0,0 +1,63 @@
+package cedra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestMoveAbortErrorMessage(t *testing.T) {
+	tests := []struct {
+		name  string
+		abort MoveAbortError
+		want  string
+	}{
+		{
+			name:  "without reason",
+			abort: MoveAbortError{VMStatus: "Move abort in 0x1::coin: 0x10006"},
+			want:  "transaction aborted: Move abort in 0x1::coin: 0x10006",
+		},
+		{
+			name:  "reason and description",
+			abort: MoveAbortError{VMStatus: "Move abort in 0x1::coin: 0x10006", Reason: "ECOIN_STORE_NOT_PUBLISHED", Description: "Account hasn't registered CoinStore"},
+			want:  "transaction aborted: Move abort in 0x1::coin: 0x10006 (ECOIN_STORE_NOT_PUBLISHED: Account hasn't registered CoinStore)",
+		},
+		{
+			name:  "reason without description",
+			abort: MoveAbortError{VMStatus: "Move abort in 0x1::coin: 0x10006", Reason: "ECOIN_STORE_NOT_PUBLISHED"},
+			want:  "transaction aborted: Move abort in 0x1::coin: 0x10006 (ECOIN_STORE_NOT_PUBLISHED)",
+		},
+		{
+			name:  "reason in status",
+			abort: MoveAbortError{VMStatus: "Move abort in 0x1::coin: ECOIN_STORE_NOT_PUBLISHED(0x60005)", Reason: "ECOIN_STORE_NOT_PUBLISHED"},
+			want:  "transaction aborted: Move abort in 0x1::coin: ECOIN_STORE_NOT_PUBLISHED(0x60005)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.abort.Error(); got != tt.want {
+				t.Fatalf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainVMStatusErrorKeepsLookupFailure(t *testing.T) {
+	client, err := NewCedraClient(TestnetChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.explainVMStatusError(ctx, &MoveAbortError{VMStatus: "Move abort in 0x1::coin: 0x10006", Location: "0x1::coin", Code: 0x10006})
+	var abort *MoveAbortError
+	if !errors.As(err, &abort) {
+		t.Fatalf("explainVMStatusError() = %v, want a *MoveAbortError", err)
+	}
+	if !errors.Is(abort.ExplainErr, context.Canceled) {
+		t.Fatalf("ExplainErr = %v, want context.Canceled", abort.ExplainErr)
+	}
+}